@@ -3,14 +3,22 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/httpfile"
+	"github.com/shiroemons/conreq/internal/i18n"
 	"github.com/shiroemons/conreq/internal/output"
+	"github.com/shiroemons/conreq/internal/redact"
 	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/terminal"
+	"github.com/shiroemons/conreq/internal/verify"
+	"github.com/shiroemons/conreq/pkg/requestid"
 	"github.com/spf13/cobra"
 )
 
@@ -43,13 +51,129 @@ func init() {
 	}
 }
 
+// exitCodeError ends the process with code without printing a message;
+// the reason has already been written as part of the report.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 func main() {
+	// フラグの説明文を作る前に言語を決める必要があるため、--langは先に読み取る
+	if err := i18n.SetLanguage(langFromArgs(os.Args[1:], i18n.Detect(os.Getenv))); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	if err := newRootCmd().Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// localizedError replaces the message of an error while keeping it
+// available to errors.Is and errors.As.
+type localizedError struct {
+	err error
+	msg string
+}
+
+func (e *localizedError) Error() string { return e.msg }
+func (e *localizedError) Unwrap() error { return e.err }
+
+// localizeError translates the locale-independent errors of pkg/requestid
+// into the selected language, even when they are wrapped inside another message.
+func localizeError(err error) error {
+	var formatErr *requestid.FormatError
+	if !errors.As(err, &formatErr) {
+		return err
+	}
+	msg := strings.Replace(err.Error(), formatErr.Error(), localizeFormatError(formatErr), 1)
+	return &localizedError{err: err, msg: msg}
+}
+
+// localizeFormatError translates a request ID format error.
+func localizeFormatError(e *requestid.FormatError) string {
+	switch {
+	case errors.Is(e, requestid.ErrInvalidName):
+		return i18n.T("requestid.invalid_name", e.Name)
+	case errors.Is(e, requestid.ErrNilFactory):
+		return i18n.T("requestid.nil_factory", e.Name)
+	case errors.Is(e, requestid.ErrDuplicate):
+		return i18n.T("requestid.duplicate", e.Name)
+	case errors.Is(e, requestid.ErrUnsupported):
+		return i18n.T("requestid.unsupported", e.Name, strings.Join(e.Available, ", "))
+	case errors.Is(e, requestid.ErrUnexpectedArgument):
+		return i18n.T("requestid.no_argument", e.Name, e.Arg)
+	case errors.Is(e, requestid.ErrInvalidLength):
+		return i18n.T("requestid.nanoid_length", e.Arg)
+	default:
+		return e.Error()
+	}
+}
+
+// langFromArgs returns the value of the last --lang flag in args, or def
+// when the flag is not given.
+func langFromArgs(args []string, def string) string {
+	lang := def
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			return lang
+		case strings.HasPrefix(arg, "--lang="):
+			lang = strings.TrimPrefix(arg, "--lang=")
+		case arg == "--lang" && i+1 < len(args):
+			i++
+			lang = args[i]
+		}
+	}
+	return lang
+}
+
+// localizeHelp translates the headings of the usage template and the
+// description of the help flag of cmd and its subcommands.
+func localizeHelp(cmd *cobra.Command) {
+	headings := strings.NewReplacer(
+		"Usage:{{", i18n.T("usage.usage")+"{{",
+		"\nAliases:\n", "\n"+i18n.T("usage.aliases")+"\n",
+		"\nExamples:\n", "\n"+i18n.T("usage.examples")+"\n",
+		"\nAvailable Commands:{{", "\n"+i18n.T("usage.available_commands")+"{{",
+		"\nAdditional Commands:{{", "\n"+i18n.T("usage.additional_commands")+"{{",
+		"\nFlags:\n", "\n"+i18n.T("usage.flags")+"\n",
+		"\nGlobal Flags:\n", "\n"+i18n.T("usage.global_flags")+"\n",
+		"\nAdditional help topics:{{", "\n"+i18n.T("usage.help_topics")+"{{",
+		`Use "{{.CommandPath}} [command] --help" for more information about a command.`, i18n.T("usage.more_info"),
+	)
+	cmd.SetUsageTemplate(headings.Replace(cmd.UsageTemplate()))
+
+	for _, c := range append([]*cobra.Command{cmd}, cmd.Commands()...) {
+		c.Flags().BoolP("help", "h", false, i18n.T("flag.help", c.Name()))
+	}
+}
+
+// verificationResult returns an exitCodeError when a verification mode failed.
+func verificationResult(cmd *cobra.Command, result *runner.Result) error {
+	if result.Config.Idempotency {
+		report, err := verify.CheckIdempotency(result)
+		if err != nil {
+			return err
+		}
+		if !report.Passed() {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return &exitCodeError{code: 1}
+		}
+	}
+	return nil
+}
+
 func newRootCmd() *cobra.Command {
 	var (
 		method          string
@@ -59,21 +183,59 @@ func newRootCmd() *cobra.Command {
 		requestID       string
 		sameRequestID   bool
 		requestIDHeader string
+		requestIDFormat string
+		idHeaders       []string
+		traceFormat     string
+		verifyEcho      bool
+		verifyEchoBody  bool
+		idempotency     bool
+		idemHeader      string
+		conflictStatus  []int
+		ignorePaths     []string
+		selectPaths     []string
+		redactHeaders   []string
+		redactJSONPaths []string
+		noRedact        bool
+		diffReport      bool
+		diffReference   int
+		diffHeaders     []string
+		ignoreHeaders   []string
+		traceState      string
 		delay           string
 		timeout         string
 		noBody          bool
 		outputJSON      bool
+		outputHAR       bool
+		outputJUnit     bool
+		outputCSV       bool
+		outputTSV       bool
+		outputHTML      bool
+		columns         []string
+		noHeader        bool
 		outputFile      string
+		outputs         []string
 		showVersion     bool
 		streamOutput    bool
+		eventsFile      string
+		fromHTTP        string
+		showHeaders     bool
+		showTimeline    bool
+		noDashboard     bool
+		interactive     bool
+		formatTemplate  string
+		formatFile      string
+		colorMode       string
+		connectTimeout  string
+		tlsTimeout      string
+		headerTimeout   string
+		bodyIdleTimeout string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "conreq [URL]",
-		Short: "同一エンドポイントへの並行HTTPリクエストツール",
-		Long: `conreqは、同一のAPIエンドポイントに対して複数の並行HTTPリクエストを送信し、
-APIの挙動を検証するためのツールです。`,
-		Args: cobra.MaximumNArgs(1),
+		Short: i18n.T("cmd.short"),
+		Long:  i18n.T("cmd.long"),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if showVersion {
 				versionStr := fmt.Sprintf("conreq version %s", version)
@@ -88,37 +250,163 @@ APIの挙動を検証するためのツールです。`,
 				return nil
 			}
 
-			if len(args) == 0 {
+			if len(args) == 0 && fromHTTP == "" {
 				return cmd.Help()
 			}
 
 			// 設定を作成
 			cfg := config.NewConfig()
-			cfg.URL = args[0]
 			cfg.Method = strings.ToUpper(method)
+			cfg.RequestIDHeader = requestIDHeader
+
+			// .httpファイルから読み込み（コマンドラインで明示したフラグが優先）
+			if fromHTTP != "" {
+				path, ref := httpfile.SplitRef(fromHTTP)
+				file, err := httpfile.ParseFile(path)
+				if err != nil {
+					return err
+				}
+				req, err := file.Lookup(ref)
+				if err != nil {
+					return err
+				}
+				if err := file.Apply(req, cfg); err != nil {
+					return err
+				}
+				if cmd.Flags().Changed("method") {
+					cfg.Method = strings.ToUpper(method)
+				}
+				if cmd.Flags().Changed("request-id-header") {
+					cfg.RequestIDHeader = requestIDHeader
+				}
+			}
+
+			if len(args) > 0 {
+				cfg.URL = args[0]
+			}
 			cfg.Count = concurrent
-			cfg.RequestID = requestID
+			if requestID != "" {
+				cfg.RequestID = requestID
+			}
 			cfg.SameRequestID = sameRequestID
-			cfg.RequestIDHeader = requestIDHeader
-			cfg.OutputJSON = outputJSON
+			cfg.RequestIDFormat = requestIDFormat
+			cfg.VerifyRequestID = verifyEcho || verifyEchoBody
+			cfg.VerifyRequestIDInBody = verifyEchoBody
+			cfg.TracePropagation = strings.ToLower(traceFormat)
+			cfg.TraceState = traceState
+			cfg.Version = version
+			cfg.Format = formatTemplate
+			if formatFile != "" {
+				if formatTemplate != "" {
+					return i18n.Errorf("cmd.format_conflict")
+				}
+				content, err := os.ReadFile(formatFile) //nolint:gosec // CLI argument
+				if err != nil {
+					return i18n.Errorf("cmd.template_file_error", err)
+				}
+				cfg.Format = string(content)
+			}
+
+			// 出力先を決定
+			// --jsonなどの形式フラグは-o（未指定時は標準出力）への出力先、--outは追加の出力先
+			if err := cfg.ParseOutputs(outputs); err != nil {
+				return err
+			}
+			var primaryFormats []string
+			for _, f := range []struct {
+				selected bool
+				format   string
+			}{
+				{outputJSON, output.FormatJSON},
+				{outputHAR, output.FormatHAR},
+				{outputJUnit, output.FormatJUnit},
+				{outputCSV, output.FormatCSV},
+				{outputTSV, output.FormatTSV},
+				{outputHTML, output.FormatHTML},
+				{cfg.Format != "", output.FormatTemplate},
+			} {
+				if f.selected {
+					primaryFormats = append(primaryFormats, f.format)
+				}
+			}
+			if len(primaryFormats) > 1 {
+				return i18n.Errorf("cmd.multiple_formats")
+			}
+			if len(outputs) == 0 || len(primaryFormats) > 0 || outputFile != "" {
+				primary := config.Output{Format: output.FormatText, Path: config.Stdout}
+				if len(primaryFormats) > 0 {
+					primary.Format = primaryFormats[0]
+				}
+				if outputFile != "" {
+					primary.Path = outputFile
+				}
+				cfg.Outputs = append([]config.Output{primary}, cfg.Outputs...)
+			}
+			cfg.Columns = columns
+			cfg.NoHeader = noHeader
 			cfg.NoBody = noBody
+			cfg.ShowHeaders = showHeaders
+			cfg.Timeline = showTimeline
+			cfg.Color = colorMode
 
 			// ヘッダーをパース
 			if err := cfg.ParseHeaders(headers); err != nil {
 				return err
 			}
 
+			// 追加の相関ヘッダーをパース
+			if err := cfg.ParseCorrelationHeaders(idHeaders); err != nil {
+				return err
+			}
+
+			// レスポンス比較の設定
+			cfg.IgnoreJSONPaths = ignorePaths
+			cfg.Select = selectPaths
+			cfg.RedactHeaders = redactHeaders
+			cfg.RedactJSONPaths = redactJSONPaths
+			cfg.NoRedact = noRedact
+			cfg.IgnoreHeaders = ignoreHeaders
+			cfg.Diff = diffReport
+			cfg.DiffReference = diffReference
+			cfg.DiffHeaders = diffHeaders
+
+			// 冪等性キー検証
+			if idempotency {
+				cfg.IdempotencyHeader = idemHeader
+				cfg.ConflictStatuses = conflictStatus
+				cfg.EnableIdempotency()
+			}
+
 			// タイムアウトをパース
 			timeoutDuration, err := config.ParseDuration(timeout)
 			if err != nil {
-				return fmt.Errorf("無効なタイムアウト形式: %w", err)
+				return i18n.Errorf("cmd.invalid_timeout", err)
 			}
 			cfg.Timeout = timeoutDuration
 
+			// フェーズ別タイムアウトをパース
+			phaseTimeouts := []struct {
+				flag  string
+				value string
+				dest  *time.Duration
+			}{
+				{"connect-timeout", connectTimeout, &cfg.ConnectTimeout},
+				{"tls-timeout", tlsTimeout, &cfg.TLSHandshakeTimeout},
+				{"response-header-timeout", headerTimeout, &cfg.ResponseHeaderTimeout},
+				{"body-idle-timeout", bodyIdleTimeout, &cfg.BodyIdleTimeout},
+			}
+			for _, pt := range phaseTimeouts {
+				d, err := config.ParseDuration(pt.value)
+				if err != nil {
+					return i18n.Errorf("cmd.invalid_phase_timeout", pt.flag, err)
+				}
+				*pt.dest = d
+			}
+
 			// 遅延時間をパース
 			delayDuration, err := config.ParseDuration(delay)
 			if err != nil {
-				return fmt.Errorf("無効な遅延時間形式: %w", err)
+				return i18n.Errorf("cmd.invalid_delay", err)
 			}
 			cfg.Delay = delayDuration
 
@@ -129,7 +417,7 @@ APIの挙動を検証するためのツールです。`,
 					filename := data[1:]
 					content, err := os.ReadFile(filename) //nolint:gosec // CLI argument
 					if err != nil {
-						return fmt.Errorf("ファイル読み込みエラー: %w", err)
+						return i18n.Errorf("cmd.read_file_error", err)
 					}
 					cfg.Body = string(content)
 				} else {
@@ -139,88 +427,216 @@ APIの挙動を検証するためのツールです。`,
 
 			// 設定を検証
 			if err := cfg.Validate(); err != nil {
+				return localizeError(err)
+			}
+			if err := output.ValidateCSVColumns(cfg.Columns); err != nil {
 				return err
 			}
+			if err := output.ValidateOutputs(cfg); err != nil {
+				return err
+			}
+
+			// 出力する結果・進行状況から秘密情報をマスクする（検証は元の値で行う）
+			redactor, err := redact.New(cfg)
+			if err != nil {
+				return err
+			}
+			outputCfg := redactor.Config(cfg)
 
 			// リクエストを実行
 			r := runner.NewRunner(cfg)
 
-			// ストリーミング出力の設定（--streamフラグが有効で、JSON出力でない場合のみ）
-			if streamOutput && !cfg.OutputJSON && outputFile == "" {
+			// NDJSONイベントストリームの出力先
+			// --events-fileの指定時はファイル、--streamを--jsonや-o、--outと併用して標準出力が空くかJSONの場合は標準出力
+			var events *output.EventFormatter
+			eventsToStdout := false
+			if eventsFile != "" {
+				file, err := os.Create(eventsFile) //nolint:gosec // CLI argument
+				if err != nil {
+					return i18n.Errorf("cmd.events_file_error", err)
+				}
+				defer func() { _ = file.Close() }()
+				events = output.NewEventFormatter(file, outputCfg)
+			} else if streamOutput && (cfg.StdoutFormat() == "" || cfg.StdoutFormat() == output.FormatJSON) {
+				events = output.NewEventFormatter(os.Stdout, outputCfg)
+				eventsToStdout = true
+			}
+
+			// 進行状況の出力先ごとに、プログレスチャネルを複製して別goroutineで監視する
+			var consumers []func(<-chan *runner.Progress) error
+			if events != nil {
+				if err := events.Start(); err != nil {
+					return i18n.Errorf("cmd.events_error", err)
+				}
+				consumers = append(consumers, func(progress <-chan *runner.Progress) error {
+					var writeErr error
+					for p := range progress {
+						if writeErr == nil {
+							writeErr = events.FormatProgress(p)
+						}
+					}
+					return writeErr
+				})
+			}
+
+			// --streamの表示は標準エラーに出す（標準出力がイベントに使われている場合は表示しない）
+			// ターミナルではその場で更新するダッシュボード、それ以外は1行ずつの表示
+			var dashboard *output.Dashboard
+			showProgress := streamOutput && !eventsToStdout
+			if showProgress && !noDashboard && terminal.IsTerminal(os.Stderr) {
+				dashboard = output.NewDashboard(os.Stderr, cfg.Count)
+				dashboard.SetColor(terminal.ColorEnabled(cfg.Color, os.Stderr))
+				consumers = append(consumers, func(progress <-chan *runner.Progress) error {
+					dashboard.Run(progress)
+					return nil
+				})
+			} else if showProgress {
 				progressFormatter := output.NewProgressFormatter(os.Stderr, cfg.Count)
+				progressFormatter.SetColor(terminal.ColorEnabled(cfg.Color, os.Stderr))
 				progressFormatter.Start()
-
-				// プログレスチャネルを別goroutineで監視
-				progressDone := make(chan struct{})
-				go func() {
-					for progress := range r.ProgressChannel() {
-						progressFormatter.FormatProgress(progress)
+				consumers = append(consumers, func(progress <-chan *runner.Progress) error {
+					for p := range progress {
+						progressFormatter.FormatProgress(p)
 					}
 					progressFormatter.Finish()
-					close(progressDone)
-				}()
+					return nil
+				})
+			}
 
-				// リクエストを実行
-				result, err := r.Run(context.Background())
-				if err != nil {
-					return err
+			progressDone := make(chan error, len(consumers))
+			if len(consumers) > 0 {
+				channels := runner.TeeProgress(redactor.Progress(r.ProgressChannel()), len(consumers))
+				for i, consume := range consumers {
+					go func() { progressDone <- consume(channels[i]) }()
 				}
-
-				// プログレス出力の完了を待つ
-				<-progressDone
-
-				// 結果を標準出力に出力
-				_, _ = fmt.Fprintln(os.Stdout, "\nFinal Results:")
-				_, _ = fmt.Fprintln(os.Stdout, "")
-				formatter := output.NewSpecTextFormatter(os.Stdout)
-				return formatter.Format(result)
 			}
 
-			// JSON出力またはファイル出力の場合は従来通り
+			// リクエストを実行
 			result, err := r.Run(context.Background())
 			if err != nil {
-				return err
+				return localizeError(err)
 			}
 
-			// 出力先を決定
-			var outputWriter *os.File
-			if outputFile != "" {
-				file, err := os.Create(outputFile) //nolint:gosec // CLI argument
-				if err != nil {
-					return fmt.Errorf("出力ファイル作成エラー: %w", err)
+			// 進行状況の出力の完了を待つ
+			var progressErr error
+			for range consumers {
+				if err := <-progressDone; err != nil && progressErr == nil {
+					progressErr = err
 				}
-				defer func() { _ = file.Close() }()
-				outputWriter = file
-			} else {
-				outputWriter = os.Stdout
+			}
+			if progressErr != nil {
+				return i18n.Errorf("cmd.events_error", progressErr)
+			}
+
+			redacted := redactor.Result(result)
+			if events != nil {
+				if err := events.Finish(redacted); err != nil {
+					return i18n.Errorf("cmd.events_error", err)
+				}
+			}
+
+			if showProgress && cfg.StdoutFormat() == output.FormatText {
+				_, _ = fmt.Fprintln(os.Stdout, "\n"+i18n.T("cmd.final_results"))
+				_, _ = fmt.Fprintln(os.Stdout, "")
 			}
 
-			// 結果を出力
-			var formatter output.Formatter
-			if cfg.OutputJSON {
-				formatter = output.NewSpecJSONFormatter(outputWriter, cfg)
-			} else {
-				formatter = output.NewSpecTextFormatter(outputWriter)
+			// 標準出力をイベントと共有する場合、JSON結果はresultイベントとして出力する
+			var stdoutFormatter output.Formatter
+			if eventsToStdout {
+				stdoutFormatter = events
+			}
+			if err := output.Dispatch(outputCfg, redacted, os.Stdout, stdoutFormatter); err != nil {
+				return err
 			}
 
-			return formatter.Format(result)
+			// --interactive指定時はキー入力でリクエストの詳細を表示（標準入力がターミナルの場合のみ）
+			if interactive && dashboard != nil && terminal.IsTerminal(os.Stdin) {
+				if restore, err := terminal.RawInput(os.Stdin); err == nil {
+					browseErr := dashboard.Browse(redacted, os.Stdin)
+					_ = restore()
+					if browseErr != nil {
+						return i18n.Errorf("cmd.key_input_error", browseErr)
+					}
+				}
+			}
+			return verificationResult(cmd, result)
 		},
 	}
 
-	cmd.Flags().StringVarP(&method, "method", "X", "GET", "HTTPメソッド (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS)")
-	cmd.Flags().IntVarP(&concurrent, "concurrent", "c", 1, "同時リクエスト数 (1-5)")
-	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "カスタムヘッダー (例: \"Content-Type: application/json\")")
-	cmd.Flags().StringVarP(&data, "data", "d", "", "リクエストボディ (@でファイル指定可)")
-	cmd.Flags().StringVar(&requestID, "request-id", "", "カスタムRequest ID値を指定")
-	cmd.Flags().BoolVar(&sameRequestID, "same-request-id", false, "全リクエストで同一のRequest IDを使用")
-	cmd.Flags().StringVar(&requestIDHeader, "request-id-header", "X-Request-ID", "Request IDヘッダー名")
-	cmd.Flags().StringVar(&delay, "delay", "0s", "リクエスト間の遅延時間 (例: \"100ms\", \"1s\")")
-	cmd.Flags().StringVar(&timeout, "timeout", "30s", "タイムアウト時間 (例: \"10s\", \"30s\")")
-	cmd.Flags().BoolVar(&noBody, "no-body", false, "レスポンスボディを非表示（JSON出力時は無視）")
-	cmd.Flags().BoolVar(&outputJSON, "json", false, "JSON形式で出力")
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "結果をファイルに出力")
-	cmd.Flags().BoolVarP(&showVersion, "version", "v", false, "バージョン情報を表示")
-	cmd.Flags().BoolVar(&streamOutput, "stream", false, "リアルタイムで進行状況を表示")
+	cmd.Flags().StringVarP(&method, "method", "X", "GET", i18n.T("flag.method"))
+	cmd.Flags().IntVarP(&concurrent, "concurrent", "c", 1, i18n.T("flag.concurrent"))
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, i18n.T("flag.header"))
+	cmd.Flags().StringVarP(&data, "data", "d", "", i18n.T("flag.data"))
+	cmd.Flags().StringVar(&requestID, "request-id", "", i18n.T("flag.request_id"))
+	cmd.Flags().BoolVar(&sameRequestID, "same-request-id", false, i18n.T("flag.same_request_id"))
+	cmd.Flags().StringVar(&requestIDHeader, "request-id-header", "X-Request-ID", i18n.T("flag.request_id_header"))
+	cmd.Flags().StringVar(&requestIDFormat, "request-id-format", requestid.DefaultFormat,
+		i18n.T("flag.request_id_format", strings.Join(requestid.Formats(), ", ")))
+	cmd.Flags().StringArrayVar(&idHeaders, "id-header", nil,
+		i18n.T("flag.id_header"))
+	cmd.Flags().BoolVar(&idempotency, "idempotency", false, i18n.T("flag.idempotency"))
+	cmd.Flags().StringVar(&idemHeader, "idempotency-header", config.DefaultIdempotencyHeader, i18n.T("flag.idempotency_header"))
+	cmd.Flags().IntSliceVar(&conflictStatus, "conflict-status", []int{409}, i18n.T("flag.conflict_status"))
+	cmd.Flags().BoolVar(&diffReport, "diff", false, i18n.T("flag.diff"))
+	cmd.Flags().IntVar(&diffReference, "diff-reference", 1, i18n.T("flag.diff_reference"))
+	cmd.Flags().StringArrayVar(&diffHeaders, "diff-header", nil, i18n.T("flag.diff_header"))
+	cmd.Flags().StringArrayVar(&ignoreHeaders, "ignore-header", nil, i18n.T("flag.ignore_header"))
+	cmd.Flags().StringArrayVar(&ignorePaths, "ignore-json-path", nil, i18n.T("flag.ignore_json_path"))
+	cmd.Flags().StringArrayVar(&selectPaths, "select", nil, i18n.T("flag.select"))
+	cmd.Flags().StringArrayVar(&redactHeaders, "redact-header", nil, i18n.T("flag.redact_header"))
+	cmd.Flags().StringArrayVar(&redactJSONPaths, "redact-json-path", nil, i18n.T("flag.redact_json_path"))
+	cmd.Flags().BoolVar(&noRedact, "no-redact", false, i18n.T("flag.no_redact"))
+	cmd.Flags().BoolVar(&verifyEcho, "verify-request-id", false, i18n.T("flag.verify_request_id"))
+	cmd.Flags().BoolVar(&verifyEchoBody, "verify-request-id-body", false, i18n.T("flag.verify_request_id_body"))
+	cmd.Flags().StringVar(&traceFormat, "trace", "", i18n.T("flag.trace"))
+	cmd.Flags().StringVar(&traceState, "tracestate", "", i18n.T("flag.tracestate"))
+	cmd.Flags().StringVar(&delay, "delay", "0s", i18n.T("flag.delay"))
+	cmd.Flags().StringVar(&timeout, "timeout", "30s", i18n.T("flag.timeout"))
+	cmd.Flags().StringVar(&connectTimeout, "connect-timeout", "", i18n.T("flag.connect_timeout"))
+	cmd.Flags().StringVar(&tlsTimeout, "tls-timeout", "", i18n.T("flag.tls_timeout"))
+	cmd.Flags().StringVar(&headerTimeout, "response-header-timeout", "", i18n.T("flag.response_header_timeout"))
+	cmd.Flags().StringVar(&bodyIdleTimeout, "body-idle-timeout", "", i18n.T("flag.body_idle_timeout"))
+	cmd.Flags().BoolVar(&noBody, "no-body", false, i18n.T("flag.no_body"))
+	cmd.Flags().BoolVar(&showHeaders, "show-headers", false, i18n.T("flag.show_headers"))
+	cmd.Flags().BoolVar(&showTimeline, "timeline", false, i18n.T("flag.timeline"))
+	cmd.Flags().StringVar(&colorMode, "color", terminal.ColorAuto, i18n.T("flag.color"))
+	cmd.Flags().BoolVar(&outputJSON, "json", false, i18n.T("flag.json"))
+	cmd.Flags().BoolVar(&outputHAR, "har", false, i18n.T("flag.har"))
+	cmd.Flags().BoolVar(&outputJUnit, "junit", false, i18n.T("flag.junit"))
+	cmd.Flags().BoolVar(&outputCSV, "csv", false, i18n.T("flag.csv"))
+	cmd.Flags().BoolVar(&outputTSV, "tsv", false, i18n.T("flag.tsv"))
+	cmd.Flags().BoolVar(&outputHTML, "html", false, i18n.T("flag.html"))
+	cmd.Flags().StringVar(&formatTemplate, "format", "", i18n.T("flag.format"))
+	cmd.Flags().StringVar(&formatFile, "format-file", "", i18n.T("flag.format_file"))
+	cmd.Flags().StringSliceVar(&columns, "columns", nil,
+		i18n.T("flag.columns", strings.Join(output.CSVColumns, ", ")))
+	cmd.Flags().BoolVar(&noHeader, "no-header", false, i18n.T("flag.no_header"))
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", i18n.T("flag.output"))
+	cmd.Flags().StringArrayVar(&outputs, "out", nil, i18n.T("flag.out"))
+	cmd.Flags().BoolVarP(&showVersion, "version", "v", false, i18n.T("flag.version"))
+	cmd.Flags().BoolVar(&streamOutput, "stream", false, i18n.T("flag.stream"))
+	cmd.Flags().BoolVar(&noDashboard, "no-dashboard", false, i18n.T("flag.no_dashboard"))
+	cmd.Flags().BoolVar(&interactive, "interactive", false, i18n.T("flag.interactive"))
+	cmd.Flags().StringVar(&eventsFile, "events-file", "", i18n.T("flag.events_file"))
+	cmd.Flags().StringVar(&fromHTTP, "from-http", "", i18n.T("flag.from_http"))
+
+	cmd.PersistentFlags().String("lang", i18n.Language(), i18n.T("flag.lang"))
+
+	cmd.AddCommand(newSchemaCmd())
+	localizeHelp(cmd)
 
 	return cmd
 }
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: i18n.T("cmd.schema_short"),
+		Long:  i18n.T("cmd.schema_long", output.SchemaVersion),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := cmd.OutOrStdout().Write(output.Schema())
+			return err
+		},
+	}
+}
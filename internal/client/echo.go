@@ -0,0 +1,39 @@
+package client
+
+import "strings"
+
+// EchoStatus is the outcome of verifying that the server echoed the request ID.
+type EchoStatus string
+
+// Echo verification outcomes.
+const (
+	EchoMatched    EchoStatus = "matched"       // レスポンスヘッダーに同じIDが返った
+	EchoInBody     EchoStatus = "found_in_body" // ヘッダーには無いがボディに含まれていた
+	EchoMissing    EchoStatus = "missing"       // どこにも返らなかった
+	EchoMismatched EchoStatus = "mismatched"    // ヘッダーに別のIDが返った
+)
+
+// EchoStatuses lists the outcomes in display order.
+var EchoStatuses = []EchoStatus{EchoMatched, EchoInBody, EchoMissing, EchoMismatched}
+
+// OK reports whether the echo is acceptable.
+func (s EchoStatus) OK() bool {
+	return s == EchoMatched || s == EchoInBody
+}
+
+// verifyEcho compares the sent request ID with the one echoed by the server.
+// When checkBody is set, a missing header falls back to searching the body.
+func verifyEcho(resp *Response, checkBody bool) EchoStatus {
+	switch {
+	case resp.RequestID == "":
+		return ""
+	case resp.ReceivedRequestID == resp.RequestID:
+		return EchoMatched
+	case resp.ReceivedRequestID != "":
+		return EchoMismatched
+	case checkBody && strings.Contains(resp.Body, resp.RequestID):
+		return EchoInBody
+	default:
+		return EchoMissing
+	}
+}
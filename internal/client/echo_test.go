@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/config"
+)
+
+func TestVerifyEcho(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      *Response
+		checkBody bool
+		want      EchoStatus
+	}{
+		{"matched", &Response{RequestID: "abc", ReceivedRequestID: "abc"}, false, EchoMatched},
+		{"mismatched", &Response{RequestID: "abc", ReceivedRequestID: "xyz"}, false, EchoMismatched},
+		{"mismatched even if in body", &Response{RequestID: "abc", ReceivedRequestID: "xyz", Body: "abc"}, true, EchoMismatched},
+		{"missing", &Response{RequestID: "abc", Body: `{"id":"abc"}`}, false, EchoMissing},
+		{"found in body", &Response{RequestID: "abc", Body: `{"id":"abc"}`}, true, EchoInBody},
+		{"missing from body", &Response{RequestID: "abc", Body: `{}`}, true, EchoMissing},
+		{"no request ID sent", &Response{}, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyEcho(tt.resp, tt.checkBody); got != tt.want {
+				t.Errorf("verifyEcho() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoKeepsSentRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-ID", "rewritten-by-gateway")
+	}))
+	defer server.Close()
+
+	cfg := config.NewConfig()
+	cfg.URL = server.URL
+	cfg.RequestID = "sent-id"
+	cfg.VerifyRequestID = true
+
+	resp := NewClient(cfg).Do(context.Background(), 0)
+	if resp.RequestID != "sent-id" {
+		t.Errorf("RequestID = %q, want sent-id", resp.RequestID)
+	}
+	if resp.ReceivedRequestID != "rewritten-by-gateway" {
+		t.Errorf("ReceivedRequestID = %q, want rewritten-by-gateway", resp.ReceivedRequestID)
+	}
+	if resp.Echo != EchoMismatched {
+		t.Errorf("Echo = %q, want %q", resp.Echo, EchoMismatched)
+	}
+}
@@ -3,39 +3,66 @@ package client
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/tracing"
 )
 
 // Response represents an HTTP response with metadata.
 type Response struct {
-	RequestID    string
-	StatusCode   int
-	Headers      http.Header
-	Body         string
-	Duration     time.Duration
-	Timestamp    time.Time
-	RequestIndex int
-	Error        error
-	StatusText   string
+	RequestID         string            // 送信したRequest ID
+	ReceivedRequestID string            // レスポンスヘッダーでエコーされたRequest ID
+	Echo              EchoStatus        // エコー検証結果（検証無効時は空）
+	CorrelationIDs    map[string]string // 追加の相関ヘッダーで送信した値
+	TraceID           string
+	SpanID            string
+	RequestHeaders    http.Header // 実際に送信したリクエストヘッダー
+	RequestBody       string      // プレースホルダー展開後のリクエストボディ
+	StatusCode        int
+	Proto             string // "HTTP/1.1" など
+	Headers           http.Header
+	Trailers          http.Header
+	Body              string
+	Duration          time.Duration
+	Timestamp         time.Time
+	RequestIndex      int
+	Error             error
+	StatusText        string
+	Timings           *Timings // ボディを読み切ったレスポンスのみ
 }
 
 // Client is an HTTP client for making concurrent requests.
 type Client struct {
 	httpClient *http.Client
 	config     *config.Config
+	received   atomic.Int64 // 受信済みのレスポンスボディのバイト数
 }
 
 // NewClient creates a new HTTP client.
+// Phase timeouts in cfg are applied to the transport; the overall timeout
+// remains http.Client.Timeout.
 func NewClient(cfg *config.Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   phaseLimit(cfg.ConnectTimeout, defaultConnectTimeout),
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = phaseLimit(cfg.TLSHandshakeTimeout, defaultTLSHandshakeTimeout)
+	transport.ResponseHeaderTimeout = cfg.ResponseHeaderTimeout
+
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
+			Timeout:   cfg.Timeout,
+			Transport: transport,
 			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
 				return http.ErrUseLastResponse
 			},
@@ -44,25 +71,44 @@ func NewClient(cfg *config.Config) *Client {
 	}
 }
 
+func phaseLimit(configured, fallback time.Duration) time.Duration {
+	if configured > 0 {
+		return configured
+	}
+	return fallback
+}
+
 // Do executes an HTTP request.
 func (c *Client) Do(ctx context.Context, requestIndex int) *Response {
 	start := time.Now()
 	response := &Response{
-		RequestIndex: requestIndex,
-		Timestamp:    start,
-		RequestID:    c.config.RequestID,
+		RequestIndex:   requestIndex,
+		Timestamp:      start,
+		RequestID:      c.config.RequestID,
+		CorrelationIDs: c.config.CorrelationValues,
+		TraceID:        c.config.TraceID,
+		SpanID:         c.config.SpanID,
 	}
 
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	tracker := &phaseTracker{}
+	ctx = httptrace.WithClientTrace(ctx, tracker.trace())
+
 	req, err := c.createRequest(ctx)
 	if err != nil {
 		response.Error = err
 		response.Duration = time.Since(start)
 		return response
 	}
+	response.RequestHeaders = req.Header.Clone()
+	if c.config.Body != "" {
+		response.RequestBody = c.expandRequestID(c.config.Body)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		response.Error = err
+		response.Error = c.classifyError(err, tracker.phase(), start)
 		response.Duration = time.Since(start)
 		return response
 	}
@@ -70,42 +116,116 @@ func (c *Client) Do(ctx context.Context, requestIndex int) *Response {
 
 	response.StatusCode = resp.StatusCode
 	response.StatusText = http.StatusText(resp.StatusCode)
+	response.Proto = resp.Proto
 	response.Headers = resp.Header
-	// レスポンスヘッダーからRequestIDを取得するか、送信したものを使用
-	if responseID := resp.Header.Get(c.config.RequestIDHeader); responseID != "" {
-		response.RequestID = responseID
-	}
+	// 送信したRequestIDは上書きせず、エコーされた値は別に保持する
+	response.ReceivedRequestID = resp.Header.Get(c.config.RequestIDHeader)
 	response.Duration = time.Since(start)
 
-	body, err := io.ReadAll(resp.Body)
+	var bodyReader io.Reader = &countingReader{r: resp.Body, n: &c.received}
+	if c.config.BodyIdleTimeout > 0 {
+		idle := newIdleReader(bodyReader, c.config.BodyIdleTimeout, cancel)
+		defer idle.stop()
+		bodyReader = idle
+	}
+
+	body, err := io.ReadAll(bodyReader)
 	if err != nil {
-		response.Error = fmt.Errorf("レスポンスボディの読み取りエラー: %w", err)
+		if errors.Is(context.Cause(ctx), errBodyIdle) {
+			err = errBodyIdle
+		}
+		response.Error = i18n.Errorf("client.read_body", c.classifyError(err, PhaseBody, start))
 		return response
 	}
 	response.Body = string(body)
+	response.Timings = tracker.timings(start, time.Now())
+	if c.config.VerifyRequestID {
+		response.Echo = verifyEcho(response, c.config.VerifyRequestIDInBody)
+	}
+	// トレーラーはボディを読み切った後でなければ確定しない
+	if len(resp.Trailer) > 0 {
+		response.Trailers = resp.Trailer
+	}
 
 	return response
 }
 
+// BytesReceived returns the number of response body bytes read so far.
+// It is safe to call while Do is running.
+func (c *Client) BytesReceived() int64 {
+	return c.received.Load()
+}
+
+// countingReader adds the number of bytes read to n.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// classifyError wraps timeout errors in a TimeoutError that names the phase
+// in progress and whether the phase timeout or the overall timeout expired.
+func (c *Client) classifyError(err error, phase string, start time.Time) error {
+	if !isTimeout(err) {
+		return err
+	}
+
+	limit := map[string]time.Duration{
+		PhaseConnect:        phaseLimit(c.config.ConnectTimeout, defaultConnectTimeout),
+		PhaseTLSHandshake:   phaseLimit(c.config.TLSHandshakeTimeout, defaultTLSHandshakeTimeout),
+		PhaseResponseHeader: c.config.ResponseHeaderTimeout,
+		PhaseBody:           c.config.BodyIdleTimeout,
+	}[phase]
+
+	if errors.Is(err, errBodyIdle) {
+		return &TimeoutError{Phase: PhaseBody, Limit: c.config.BodyIdleTimeout, Err: err}
+	}
+	if limit <= 0 || (c.config.Timeout > 0 && time.Since(start) >= c.config.Timeout) {
+		return &TimeoutError{Phase: phase, Limit: c.config.Timeout, Total: true, Err: err}
+	}
+	// 未設定の接続・TLSハンドシェイクのタイムアウトにはトランスポートの既定値が使われる
+	configured := map[string]time.Duration{
+		PhaseConnect:      c.config.ConnectTimeout,
+		PhaseTLSHandshake: c.config.TLSHandshakeTimeout,
+	}
+	if d, ok := configured[phase]; ok && d <= 0 {
+		return &TimeoutError{Phase: phase, Limit: limit, Default: true, Err: err}
+	}
+	return &TimeoutError{Phase: phase, Limit: limit, Err: err}
+}
+
 func (c *Client) createRequest(ctx context.Context) (*http.Request, error) {
 	var body io.Reader
 	if c.config.Body != "" {
-		body = strings.NewReader(c.config.Body)
+		body = strings.NewReader(c.expandRequestID(c.config.Body))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, c.config.Method, c.config.URL, body)
 	if err != nil {
-		return nil, fmt.Errorf("リクエスト作成エラー: %w", err)
+		return nil, i18n.Errorf("client.create_request", err)
 	}
 
 	for key, value := range c.config.Headers {
-		req.Header.Set(key, value)
+		req.Header.Set(key, c.expandRequestID(value))
 	}
 
 	if c.config.RequestID != "" {
 		req.Header.Set(c.config.RequestIDHeader, c.config.RequestID)
 	}
 
+	for key, value := range c.config.CorrelationValues {
+		req.Header.Set(key, value)
+	}
+
+	for key, value := range tracing.Headers(c.config.TracePropagation, c.config.TraceID, c.config.SpanID, c.config.TraceState) {
+		req.Header.Set(key, value)
+	}
+
 	if body != nil && req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -113,6 +233,11 @@ func (c *Client) createRequest(ctx context.Context) (*http.Request, error) {
 	return req, nil
 }
 
+// expandRequestID replaces the request ID placeholder with the ID of this request.
+func (c *Client) expandRequestID(s string) string {
+	return strings.ReplaceAll(s, config.RequestIDPlaceholder, c.config.RequestID)
+}
+
 // DoWithDelay executes an HTTP request with a delay.
 func (c *Client) DoWithDelay(ctx context.Context, requestIndex int, delay time.Duration) *Response {
 	if delay > 0 {
@@ -122,10 +247,13 @@ func (c *Client) DoWithDelay(ctx context.Context, requestIndex int, delay time.D
 		case <-ctx.Done():
 			timer.Stop()
 			return &Response{
-				RequestIndex: requestIndex,
-				Error:        ctx.Err(),
-				Timestamp:    time.Now(),
-				RequestID:    c.config.RequestID,
+				RequestIndex:   requestIndex,
+				Error:          ctx.Err(),
+				Timestamp:      time.Now(),
+				RequestID:      c.config.RequestID,
+				CorrelationIDs: c.config.CorrelationValues,
+				TraceID:        c.config.TraceID,
+				SpanID:         c.config.SpanID,
 			}
 		}
 	}
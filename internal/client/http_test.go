@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+func TestDoCapturesHeadersAndTrailers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+		w.Header().Set("Grpc-Status", "0")
+	}))
+	defer server.Close()
+
+	cfg := config.NewConfig()
+	cfg.URL = server.URL
+
+	resp := NewClient(cfg).Do(context.Background(), 0)
+	if resp.Error != nil {
+		t.Fatalf("Do() error = %v", resp.Error)
+	}
+
+	if got := resp.Headers.Values("Set-Cookie"); len(got) != 2 {
+		t.Errorf("Set-Cookie values = %v, want 2 values", got)
+	}
+	if got := resp.Trailers.Get("Grpc-Status"); got != "0" {
+		t.Errorf("Grpc-Status trailer = %q, want %q", got, "0")
+	}
+}
+
+func TestDoTimeoutPhases(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		configure func(cfg *config.Config)
+		wantPhase string
+		wantTotal bool
+	}{
+		{
+			name: "response header timeout",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			},
+			configure: func(cfg *config.Config) {
+				cfg.ResponseHeaderTimeout = 50 * time.Millisecond
+			},
+			wantPhase: PhaseResponseHeader,
+		},
+		{
+			name: "body idle timeout",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("partial"))
+				w.(http.Flusher).Flush()
+				time.Sleep(200 * time.Millisecond)
+			},
+			configure: func(cfg *config.Config) {
+				cfg.BodyIdleTimeout = 50 * time.Millisecond
+			},
+			wantPhase: PhaseBody,
+		},
+		{
+			name: "total timeout while awaiting headers",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			},
+			configure: func(cfg *config.Config) {
+				cfg.Timeout = 50 * time.Millisecond
+			},
+			wantPhase: PhaseResponseHeader,
+			wantTotal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			cfg := config.NewConfig()
+			cfg.URL = server.URL
+			tt.configure(cfg)
+
+			resp := NewClient(cfg).Do(context.Background(), 0)
+			var timeoutErr *TimeoutError
+			if !errors.As(resp.Error, &timeoutErr) {
+				t.Fatalf("Do() error = %v, want *TimeoutError", resp.Error)
+			}
+			if timeoutErr.Phase != tt.wantPhase {
+				t.Errorf("Phase = %q, want %q", timeoutErr.Phase, tt.wantPhase)
+			}
+			if timeoutErr.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", timeoutErr.Total, tt.wantTotal)
+			}
+			if !strings.Contains(resp.Error.Error(), tt.wantPhase) {
+				t.Errorf("error message %q does not name the phase", resp.Error)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorDefaultLimit(t *testing.T) {
+	if err := i18n.SetLanguage(i18n.English); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.SetLanguage("") })
+
+	tests := []struct {
+		name        string
+		configure   func(cfg *config.Config)
+		phase       string
+		wantLimit   time.Duration
+		wantDefault bool
+		wantMessage string
+	}{
+		{
+			name:        "connect timeout not configured",
+			configure:   func(_ *config.Config) {},
+			phase:       PhaseConnect,
+			wantLimit:   defaultConnectTimeout,
+			wantDefault: true,
+			wantMessage: "connect timeout (default 30s) exceeded",
+		},
+		{
+			name:        "TLS handshake timeout not configured",
+			configure:   func(_ *config.Config) {},
+			phase:       PhaseTLSHandshake,
+			wantLimit:   defaultTLSHandshakeTimeout,
+			wantDefault: true,
+			wantMessage: "tls_handshake timeout (default 10s) exceeded",
+		},
+		{
+			name:        "connect timeout configured",
+			configure:   func(cfg *config.Config) { cfg.ConnectTimeout = 2 * time.Second },
+			phase:       PhaseConnect,
+			wantLimit:   2 * time.Second,
+			wantMessage: "connect timeout (2s) exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			tt.configure(cfg)
+
+			err := NewClient(cfg).classifyError(context.DeadlineExceeded, tt.phase, time.Now())
+			var timeoutErr *TimeoutError
+			if !errors.As(err, &timeoutErr) {
+				t.Fatalf("classifyError() = %v, want *TimeoutError", err)
+			}
+			if timeoutErr.Limit != tt.wantLimit || timeoutErr.Default != tt.wantDefault || timeoutErr.Total {
+				t.Errorf("TimeoutError = %+v, want limit %s, default %v", timeoutErr, tt.wantLimit, tt.wantDefault)
+			}
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("Error() = %q, want it to contain %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// Timeout phases reported by TimeoutError.
+const (
+	PhaseConnect        = "connect"
+	PhaseTLSHandshake   = "tls_handshake"
+	PhaseResponseHeader = "response_header"
+	PhaseBody           = "body"
+)
+
+// Transport defaults used when a phase timeout is not configured.
+const (
+	defaultConnectTimeout      = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// errBodyIdle is the cancellation cause used when the body idle timeout expires.
+var errBodyIdle = errors.New("body idle timeout")
+
+// TimeoutError reports which phase of a request was in progress when a timeout expired.
+type TimeoutError struct {
+	// Phase is one of PhaseConnect, PhaseTLSHandshake, PhaseResponseHeader or PhaseBody.
+	Phase string
+	// Limit is the timeout that expired.
+	Limit time.Duration
+	// Total is true when the overall request timeout expired rather than the phase timeout.
+	Total bool
+	// Default is true when Limit is the transport default because the phase
+	// timeout was not configured.
+	Default bool
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	switch {
+	case e.Total:
+		return i18n.T("client.total_timeout", e.Limit, e.Phase, e.Err)
+	case e.Default:
+		return i18n.T("client.default_phase_timeout", e.Phase, e.Limit, e.Err)
+	}
+	return i18n.T("client.phase_timeout", e.Phase, e.Limit, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// phaseTracker records how far a request progressed, and when, using httptrace hooks.
+type phaseTracker struct {
+	mu           sync.Mutex
+	getConn      time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+func (t *phaseTracker) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(_ string) {
+			t.mark(&t.getConn)
+		},
+		DNSStart: func(_ httptrace.DNSStartInfo) {
+			t.mark(&t.dnsStart)
+		},
+		DNSDone: func(_ httptrace.DNSDoneInfo) {
+			t.mark(&t.dnsDone)
+		},
+		ConnectStart: func(_, _ string) {
+			t.mark(&t.connectStart)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.mark(&t.connectDone)
+			}
+		},
+		GotConn: func(_ httptrace.GotConnInfo) {
+			t.mark(&t.gotConn)
+		},
+		TLSHandshakeStart: func() {
+			t.mark(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, _ error) {
+			t.mark(&t.tlsDone)
+		},
+		WroteRequest: func(_ httptrace.WroteRequestInfo) {
+			t.mark(&t.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			t.mark(&t.firstByte)
+		},
+	}
+}
+
+// mark records the current time; only the first occurrence of an event is kept.
+func (t *phaseTracker) mark(at *time.Time) {
+	t.mu.Lock()
+	if at.IsZero() {
+		*at = time.Now()
+	}
+	t.mu.Unlock()
+}
+
+// phase returns the phase the request was in.
+func (t *phaseTracker) phase() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case !t.tlsStart.IsZero() && t.tlsDone.IsZero():
+		return PhaseTLSHandshake
+	case t.connectDone.IsZero() && t.gotConn.IsZero():
+		return PhaseConnect
+	case t.firstByte.IsZero():
+		return PhaseResponseHeader
+	default:
+		return PhaseBody
+	}
+}
+
+// isTimeout reports whether err was caused by a deadline or timeout.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, errBodyIdle) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// idleReader cancels the request when no data arrives within the idle timeout.
+type idleReader struct {
+	r     io.Reader
+	timer *time.Timer
+	idle  time.Duration
+}
+
+func newIdleReader(r io.Reader, idle time.Duration, cancel context.CancelCauseFunc) *idleReader {
+	return &idleReader{
+		r:     r,
+		idle:  idle,
+		timer: time.AfterFunc(idle, func() { cancel(errBodyIdle) }),
+	}
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.idle)
+	}
+	return n, err
+}
+
+func (r *idleReader) stop() {
+	r.timer.Stop()
+}
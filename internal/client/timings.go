@@ -0,0 +1,62 @@
+package client
+
+import "time"
+
+// Timings holds the duration of each phase of a request, observed with
+// httptrace. The phases follow the HAR 1.2 timings object: a phase that did
+// not take place, such as DNS lookup on a reused connection, is -1.
+type Timings struct {
+	Blocked      time.Duration // waiting for a connection, excluding DNS and connect
+	DNS          time.Duration
+	Connect      time.Duration // TCP connect including the TLS handshake
+	TLSHandshake time.Duration
+	Send         time.Duration // writing the request
+	Wait         time.Duration // waiting for the first response byte
+	Receive      time.Duration // reading the response body
+}
+
+// timings converts the recorded events into phase durations.
+// start is when the request was issued and end when the body was read.
+func (t *phaseTracker) timings(start, end time.Time) *Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := &Timings{
+		Blocked:      -1,
+		DNS:          span(t.dnsStart, t.dnsDone),
+		Connect:      span(t.connectStart, t.connectDone),
+		TLSHandshake: span(t.tlsStart, t.tlsDone),
+	}
+	if timings.Connect >= 0 && timings.TLSHandshake >= 0 {
+		timings.Connect = span(t.connectStart, t.tlsDone)
+	}
+
+	// 接続待ちはDNS解決または接続開始まで（再利用時は接続取得まで）
+	getConn := t.getConn
+	if getConn.IsZero() {
+		getConn = start
+	}
+	for _, next := range []time.Time{t.dnsStart, t.connectStart, t.gotConn} {
+		if !next.IsZero() {
+			timings.Blocked = max(next.Sub(getConn), 0)
+			break
+		}
+	}
+
+	timings.Send = nonNegative(span(t.gotConn, t.wroteRequest))
+	timings.Wait = nonNegative(span(t.wroteRequest, t.firstByte))
+	timings.Receive = nonNegative(span(t.firstByte, end))
+	return timings
+}
+
+// span returns to-from, or -1 when either event was not observed.
+func span(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() {
+		return -1
+	}
+	return to.Sub(from)
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	return max(d, 0)
+}
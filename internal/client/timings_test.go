@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+)
+
+func TestDoRecordsTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := config.NewConfig()
+	cfg.URL = server.URL
+	cfg.Method = http.MethodPost
+	cfg.RequestID = "req-1"
+	cfg.Body = `{"id":"{{$requestId}}"}`
+
+	resp := NewClient(cfg).Do(context.Background(), 0)
+	if resp.Error != nil {
+		t.Fatalf("Do() error = %v", resp.Error)
+	}
+
+	if resp.Timings == nil {
+		t.Fatal("Timings = nil")
+	}
+	timings := resp.Timings
+	if timings.Connect < 0 {
+		t.Errorf("Connect = %v, want a new connection to be measured", timings.Connect)
+	}
+	// httptest.NewServerはIPアドレスのためDNS解決もTLSも発生しない
+	if timings.DNS != -1 || timings.TLSHandshake != -1 {
+		t.Errorf("DNS = %v, TLSHandshake = %v, want -1", timings.DNS, timings.TLSHandshake)
+	}
+	if timings.Wait < 20*time.Millisecond {
+		t.Errorf("Wait = %v, want >= 20ms", timings.Wait)
+	}
+	if timings.Blocked < 0 || timings.Send < 0 || timings.Receive < 0 {
+		t.Errorf("Timings = %+v, want non-negative blocked, send and receive", timings)
+	}
+
+	if resp.Proto != "HTTP/1.1" {
+		t.Errorf("Proto = %q, want HTTP/1.1", resp.Proto)
+	}
+	if got := resp.RequestHeaders.Get("X-Request-ID"); got != "req-1" {
+		t.Errorf("RequestHeaders X-Request-ID = %q, want req-1", got)
+	}
+	if resp.RequestBody != `{"id":"req-1"}` {
+		t.Errorf("RequestBody = %q", resp.RequestBody)
+	}
+}
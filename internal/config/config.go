@@ -2,12 +2,21 @@
 package config
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/terminal"
+	"github.com/shiroemons/conreq/internal/tracing"
+	"github.com/shiroemons/conreq/pkg/requestid"
 )
 
+// RequestIDPlaceholder is replaced by the request ID of each request when it
+// appears in a header value or the request body.
+const RequestIDPlaceholder = "{{$requestId}}"
+
 // Config holds all configuration parameters for concurrent requests.
 type Config struct {
 	URL             string
@@ -18,46 +27,149 @@ type Config struct {
 	RequestID       string
 	SameRequestID   bool
 	RequestIDHeader string
-	Delay           time.Duration
-	Timeout         time.Duration
-	OutputJSON      bool
-	NoBody          bool
+	RequestIDFormat string
+	// 追加の相関ヘッダー（CorrelationValuesはリクエストごとにRunnerが設定する）
+	CorrelationHeaders []CorrelationHeader
+	CorrelationValues  map[string]string
+	// 冪等性キー検証
+	Idempotency       bool
+	IdempotencyHeader string
+	ConflictStatuses  []int
+	IgnoreJSONPaths   []string
+	// レスポンス間の差分レポート
+	Diff          bool
+	DiffReference int
+	DiffHeaders   []string
+	IgnoreHeaders []string
+	// Request IDのエコー検証
+	VerifyRequestID       bool
+	VerifyRequestIDInBody bool
+	// トレースコンテキスト伝播（TraceID・SpanIDはリクエストごとにRunnerが設定する）
+	TracePropagation string
+	TraceState       string
+	TraceID          string
+	SpanID           string
+	Delay            time.Duration
+	Timeout          time.Duration
+	// フェーズ別タイムアウト（0の場合はトランスポートの既定値または無制限）
+	ConnectTimeout        time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	BodyIdleTimeout       time.Duration
+	Outputs               []Output // 結果の出力先（複数指定可）
+	Format                string   // --format/--format-file のGoテンプレート
+	Version               string   // conreqのバージョン（HARのcreatorに記録）
+	Columns               []string // CSV/TSVの出力列（未指定時は既定の列）
+	NoHeader              bool     // CSV/TSVのヘッダー行を出力しない
+	NoBody                bool
+	ShowHeaders           bool
+	Select                []string // 結果に表示するJSONPath（指定時はボディの代わりに表示）
+	Timeline              bool     // テキスト出力にタイムラインを表示
+	Color                 string   // auto, always, never
+	// 出力時の秘密情報のマスク
+	RedactHeaders   []string // 既定のヘッダーに加えてマスクするヘッダー
+	RedactJSONPaths []string // マスクするJSONボディのフィールド
+	NoRedact        bool     // マスクを無効にする
 }
 
 // NewConfig creates a new Config with default values.
 func NewConfig() *Config {
 	return &Config{
-		Method:          "GET",
-		Count:           1,
-		Headers:         make(map[string]string),
-		Timeout:         30 * time.Second,
-		Delay:           0,
-		RequestIDHeader: "X-Request-ID",
-		SameRequestID:   false,
-		NoBody:          false,
+		Method:            "GET",
+		Count:             1,
+		Headers:           make(map[string]string),
+		Timeout:           30 * time.Second,
+		Delay:             0,
+		RequestIDHeader:   "X-Request-ID",
+		RequestIDFormat:   requestid.DefaultFormat,
+		IdempotencyHeader: DefaultIdempotencyHeader,
+		ConflictStatuses:  []int{http.StatusConflict},
+		DiffReference:     1,
+		Color:             terminal.ColorAuto,
+		SameRequestID:     false,
+		NoBody:            false,
 	}
 }
 
 // Validate checks if the configuration is valid.
 func (c *Config) Validate() error {
 	if c.URL == "" {
-		return fmt.Errorf("URLが指定されていません")
+		return i18n.Errorf("config.url_required")
 	}
 
 	if !isValidHTTPMethod(c.Method) {
-		return fmt.Errorf("無効なHTTPメソッド: %s", c.Method)
+		return i18n.Errorf("config.invalid_method", c.Method)
 	}
 
 	if c.Count < 1 || c.Count > 5 {
-		return fmt.Errorf("同時リクエスト数は1-5の範囲で指定してください: %d", c.Count)
+		return i18n.Errorf("config.invalid_count", c.Count)
 	}
 
 	if c.Timeout <= 0 {
-		return fmt.Errorf("タイムアウトは正の値を指定してください: %s", c.Timeout)
+		return i18n.Errorf("config.invalid_timeout", c.Timeout)
 	}
 
 	if c.Delay < 0 {
-		return fmt.Errorf("遅延時間は0以上の値を指定してください: %s", c.Delay)
+		return i18n.Errorf("config.invalid_delay", c.Delay)
+	}
+
+	if _, err := requestid.New(c.RequestIDFormat); err != nil {
+		return err
+	}
+
+	if err := c.validateCorrelationHeaders(); err != nil {
+		return err
+	}
+
+	if _, err := jsonpath.ParseAll(c.IgnoreJSONPaths); err != nil {
+		return err
+	}
+
+	if _, err := jsonpath.ParseAll(c.Select); err != nil {
+		return err
+	}
+
+	if _, err := jsonpath.ParseAll(c.RedactJSONPaths); err != nil {
+		return err
+	}
+
+	if c.NoRedact && (len(c.RedactHeaders) > 0 || len(c.RedactJSONPaths) > 0) {
+		return i18n.Errorf("config.redact_conflict")
+	}
+
+	if c.Diff && (c.DiffReference < 1 || c.DiffReference > c.Count) {
+		return i18n.Errorf("config.invalid_diff_reference", c.Count, c.DiffReference)
+	}
+
+	if c.Idempotency && c.Count < 2 {
+		return i18n.Errorf("config.idempotency_count", c.Count)
+	}
+
+	if err := c.validateOutputs(); err != nil {
+		return err
+	}
+
+	if !terminal.IsValidColorMode(c.Color) {
+		return i18n.Errorf("config.invalid_color", c.Color, strings.Join(terminal.ColorModes, ", "))
+	}
+
+	if !tracing.IsValidFormat(c.TracePropagation) {
+		return i18n.Errorf("config.invalid_trace", c.TracePropagation, strings.Join(tracing.Formats, ", "))
+	}
+
+	phaseTimeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{i18n.T("config.connect_timeout"), c.ConnectTimeout},
+		{i18n.T("config.tls_timeout"), c.TLSHandshakeTimeout},
+		{i18n.T("config.response_header_timeout"), c.ResponseHeaderTimeout},
+		{i18n.T("config.body_idle_timeout"), c.BodyIdleTimeout},
+	}
+	for _, t := range phaseTimeouts {
+		if t.value < 0 {
+			return i18n.Errorf("config.negative_phase_timeout", t.name, t.value)
+		}
 	}
 
 	return nil
@@ -68,7 +180,7 @@ func (c *Config) ParseHeaders(headers []string) error {
 	for _, header := range headers {
 		parts := strings.SplitN(header, ":", 2)
 		if len(parts) != 2 {
-			return fmt.Errorf("無効なヘッダー形式: %s", header)
+			return i18n.Errorf("config.invalid_header", header)
 		}
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
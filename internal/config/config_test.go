@@ -102,6 +102,73 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative phase timeout",
+			config: &Config{
+				URL:                   "https://example.com",
+				Method:                "GET",
+				Count:                 1,
+				Timeout:               30 * time.Second,
+				ResponseHeaderTimeout: -1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple outputs to stdout",
+			config: &Config{
+				URL:     "https://example.com",
+				Method:  "GET",
+				Count:   1,
+				Timeout: 30 * time.Second,
+				Outputs: []Output{{Format: "text", Path: Stdout}, {Format: "json", Path: Stdout}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate output file",
+			config: &Config{
+				URL:     "https://example.com",
+				Method:  "GET",
+				Count:   1,
+				Timeout: 30 * time.Second,
+				Outputs: []Output{{Format: "json", Path: "out/result.json"}, {Format: "har", Path: "./out/result.json"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid select path",
+			config: &Config{
+				URL:     "https://example.com",
+				Method:  "GET",
+				Count:   1,
+				Timeout: 30 * time.Second,
+				Select:  []string{"$.items[x]"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid redact path",
+			config: &Config{
+				URL:             "https://example.com",
+				Method:          "GET",
+				Count:           1,
+				Timeout:         30 * time.Second,
+				RedactJSONPaths: []string{"$.token["},
+			},
+			wantErr: true,
+		},
+		{
+			name: "redact rules with no-redact",
+			config: &Config{
+				URL:           "https://example.com",
+				Method:        "GET",
+				Count:         1,
+				Timeout:       30 * time.Second,
+				RedactHeaders: []string{"X-Session"},
+				NoRedact:      true,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
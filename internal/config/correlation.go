@@ -0,0 +1,74 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/pkg/requestid"
+)
+
+// CorrelationHeader is an additional generated header such as Idempotency-Key,
+// sent alongside the request ID header with its own policy.
+type CorrelationHeader struct {
+	Name   string
+	Format string // requestidの生成形式（空の場合はUUID v4）
+	Shared bool   // trueの場合は全リクエストで同一の値
+	Value  string // 固定値（指定時は生成しない）
+}
+
+// ParseCorrelationHeader parses a specification of the form
+// "NAME[,shared|per-request][,format=FORMAT][,value=VALUE]".
+func ParseCorrelationHeader(spec string) (CorrelationHeader, error) {
+	parts := strings.Split(spec, ",")
+	h := CorrelationHeader{Name: strings.TrimSpace(parts[0])}
+	if h.Name == "" {
+		return h, i18n.Errorf("config.invalid_correlation_header", spec)
+	}
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, value, hasValue := strings.Cut(part, "=")
+		switch {
+		case part == "shared":
+			h.Shared = true
+		case part == "per-request":
+			h.Shared = false
+		case hasValue && key == "format":
+			h.Format = value
+		case hasValue && key == "value":
+			h.Value = value
+		default:
+			return h, i18n.Errorf("config.invalid_correlation_option", part, spec)
+		}
+	}
+	return h, nil
+}
+
+// ParseCorrelationHeaders parses header specifications and adds them to the config.
+func (c *Config) ParseCorrelationHeaders(specs []string) error {
+	for _, spec := range specs {
+		h, err := ParseCorrelationHeader(spec)
+		if err != nil {
+			return err
+		}
+		c.CorrelationHeaders = append(c.CorrelationHeaders, h)
+	}
+	return nil
+}
+
+func (c *Config) validateCorrelationHeaders() error {
+	seen := map[string]bool{http.CanonicalHeaderKey(c.RequestIDHeader): true}
+	for _, h := range c.CorrelationHeaders {
+		key := http.CanonicalHeaderKey(h.Name)
+		if seen[key] {
+			return i18n.Errorf("config.duplicate_correlation_header", h.Name)
+		}
+		seen[key] = true
+
+		if _, err := requestid.New(h.Format); err != nil {
+			return i18n.Errorf("config.correlation_header", h.Name, err)
+		}
+	}
+	return nil
+}
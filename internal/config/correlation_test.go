@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCorrelationHeader(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    CorrelationHeader
+		wantErr bool
+	}{
+		{"Idempotency-Key", CorrelationHeader{Name: "Idempotency-Key"}, false},
+		{"Idempotency-Key,shared", CorrelationHeader{Name: "Idempotency-Key", Shared: true}, false},
+		{"X-Trace-Key, per-request, format=ulid", CorrelationHeader{Name: "X-Trace-Key", Format: "ulid"}, false},
+		{"X-Seq,format=sequential:job", CorrelationHeader{Name: "X-Seq", Format: "sequential:job"}, false},
+		{"X-Tenant,value=acme", CorrelationHeader{Name: "X-Tenant", Value: "acme"}, false},
+		{"", CorrelationHeader{}, true},
+		{"X-Bad,unknown", CorrelationHeader{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseCorrelationHeader(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCorrelationHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseCorrelationHeader() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCorrelationHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []CorrelationHeader
+		wantErr bool
+	}{
+		{"valid", []CorrelationHeader{{Name: "Idempotency-Key", Shared: true}, {Name: "X-Other", Format: "ulid"}}, false},
+		{"duplicate", []CorrelationHeader{{Name: "Idempotency-Key"}, {Name: "idempotency-key"}}, true},
+		{"same as request ID header", []CorrelationHeader{{Name: "x-request-id"}}, true},
+		{"unknown format", []CorrelationHeader{{Name: "X-Other", Format: "unknown"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				URL:                "https://example.com",
+				Method:             "GET",
+				Count:              1,
+				Timeout:            30 * time.Second,
+				RequestIDHeader:    "X-Request-ID",
+				CorrelationHeaders: tt.headers,
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultIdempotencyHeader is the header used by idempotency-key verification.
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// EnableIdempotency turns on idempotency-key verification and makes sure every
+// request sends the same key in IdempotencyHeader. An existing correlation
+// header of the same name is made shared (its fixed value, if any, is kept),
+// a static -H header of the same name becomes the fixed key, and when the
+// header is the request ID header, SameRequestID is enabled instead.
+func (c *Config) EnableIdempotency() {
+	c.Idempotency = true
+
+	if strings.EqualFold(c.IdempotencyHeader, c.RequestIDHeader) {
+		c.IdempotencyHeader = c.RequestIDHeader
+		c.SameRequestID = true
+		return
+	}
+
+	for i, h := range c.CorrelationHeaders {
+		if http.CanonicalHeaderKey(h.Name) == http.CanonicalHeaderKey(c.IdempotencyHeader) {
+			c.CorrelationHeaders[i].Shared = true
+			c.IdempotencyHeader = h.Name
+			return
+		}
+	}
+
+	// -H で固定値が指定されている場合はその値を共有キーとして使う
+	h := CorrelationHeader{Name: c.IdempotencyHeader, Shared: true}
+	for key, value := range c.Headers {
+		if strings.EqualFold(key, c.IdempotencyHeader) {
+			h.Value = value
+			delete(c.Headers, key)
+		}
+	}
+	c.CorrelationHeaders = append(c.CorrelationHeaders, h)
+}
@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestEnableIdempotency(t *testing.T) {
+	t.Run("adds a shared correlation header", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.EnableIdempotency()
+
+		if !cfg.Idempotency {
+			t.Error("Idempotency = false, want true")
+		}
+		if len(cfg.CorrelationHeaders) != 1 || !cfg.CorrelationHeaders[0].Shared || cfg.CorrelationHeaders[0].Name != "Idempotency-Key" {
+			t.Errorf("CorrelationHeaders = %+v", cfg.CorrelationHeaders)
+		}
+	})
+
+	t.Run("uses a static header as the fixed key", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.Headers["idempotency-key"] = "fixed"
+		cfg.EnableIdempotency()
+
+		if _, ok := cfg.Headers["idempotency-key"]; ok {
+			t.Error("static header should be moved to the correlation header")
+		}
+		if got := cfg.CorrelationHeaders[0].Value; got != "fixed" {
+			t.Errorf("Value = %q, want fixed", got)
+		}
+	})
+
+	t.Run("makes an existing correlation header shared", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.CorrelationHeaders = []CorrelationHeader{{Name: "idempotency-key", Format: "ulid"}}
+		cfg.EnableIdempotency()
+
+		if len(cfg.CorrelationHeaders) != 1 || !cfg.CorrelationHeaders[0].Shared {
+			t.Errorf("CorrelationHeaders = %+v", cfg.CorrelationHeaders)
+		}
+		if cfg.IdempotencyHeader != "idempotency-key" {
+			t.Errorf("IdempotencyHeader = %q", cfg.IdempotencyHeader)
+		}
+	})
+
+	t.Run("request ID header enables same request ID", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.IdempotencyHeader = "x-request-id"
+		cfg.EnableIdempotency()
+
+		if !cfg.SameRequestID || len(cfg.CorrelationHeaders) != 0 {
+			t.Errorf("SameRequestID = %v, CorrelationHeaders = %+v", cfg.SameRequestID, cfg.CorrelationHeaders)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// Stdout is the output path that writes to standard output.
+const Stdout = "-"
+
+// Output is a destination for the formatted result: a format registered in
+// the output package and the file it is written to.
+type Output struct {
+	Format string // text, json, har, junit, csv, tsv, html, template
+	Path   string // Stdout の場合は標準出力
+}
+
+// ParseOutput parses a specification of the form "FORMAT[:PATH]".
+// The path defaults to Stdout.
+func ParseOutput(spec string) (Output, error) {
+	format, path, _ := strings.Cut(spec, ":")
+	o := Output{Format: strings.ToLower(strings.TrimSpace(format)), Path: strings.TrimSpace(path)}
+	if o.Format == "" {
+		return o, i18n.Errorf("config.invalid_output", spec)
+	}
+	if o.Path == "" {
+		o.Path = Stdout
+	}
+	return o, nil
+}
+
+// ParseOutputs parses output specifications and adds them to the config.
+func (c *Config) ParseOutputs(specs []string) error {
+	for _, spec := range specs {
+		o, err := ParseOutput(spec)
+		if err != nil {
+			return err
+		}
+		c.Outputs = append(c.Outputs, o)
+	}
+	return nil
+}
+
+// StdoutFormat returns the format written to standard output, or "" when
+// every output goes to a file.
+func (c *Config) StdoutFormat() string {
+	for _, o := range c.Outputs {
+		if o.Path == Stdout {
+			return o.Format
+		}
+	}
+	return ""
+}
+
+func (c *Config) validateOutputs() error {
+	seen := make(map[string]bool, len(c.Outputs))
+	for _, o := range c.Outputs {
+		key := o.Path
+		if key != Stdout {
+			key = filepath.Clean(key)
+		}
+		if seen[key] {
+			if o.Path == Stdout {
+				return i18n.Errorf("config.multiple_stdout")
+			}
+			return i18n.Errorf("config.duplicate_output", o.Path)
+		}
+		seen[key] = true
+	}
+	return nil
+}
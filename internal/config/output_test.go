@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Output
+		wantErr bool
+	}{
+		{"text", Output{Format: "text", Path: Stdout}, false},
+		{"text:-", Output{Format: "text", Path: Stdout}, false},
+		{"JSON:result.json", Output{Format: "json", Path: "result.json"}, false},
+		{"junit: reports/report.xml", Output{Format: "junit", Path: "reports/report.xml"}, false},
+		{`html:C:\reports\report.html`, Output{Format: "html", Path: `C:\reports\report.html`}, false},
+		{"", Output{}, true},
+		{":result.json", Output{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseOutput(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigStdoutFormat(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ParseOutputs([]string{"json:result.json", "junit:report.xml"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.StdoutFormat(); got != "" {
+		t.Errorf("StdoutFormat() = %q, want empty", got)
+	}
+
+	if err := cfg.ParseOutputs([]string{"text:-"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.StdoutFormat(); got != "text" {
+		t.Errorf("StdoutFormat() = %q, want text", got)
+	}
+	if len(cfg.Outputs) != 3 {
+		t.Errorf("len(Outputs) = %d, want 3", len(cfg.Outputs))
+	}
+}
@@ -0,0 +1,362 @@
+// Package httpfile parses VS Code REST Client / JetBrains HTTP Client (.http) files.
+package httpfile
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/pkg/requestid"
+)
+
+// requestIDVariableName is the file variable that sets a fixed request ID.
+const requestIDVariableName = "requestId"
+
+var (
+	variablePattern   = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+	definitionPattern = regexp.MustCompile(`^@([A-Za-z_][\w.-]*)\s*=\s*(.*)$`)
+	namePattern       = regexp.MustCompile(`^(?:#|//)\s*@name\s+(\S+)`)
+	requestLineRegexp = regexp.MustCompile(`^([A-Za-z]+|\{\{[^{}]*\}\})\s+((?:\{\{[^{}]*\}\}|\S)+)(?:\s+HTTP/[\d.]+)?$`)
+)
+
+// Request represents a single request defined in a .http file.
+type Request struct {
+	Name    string
+	Method  string
+	URL     string
+	Headers []Header
+	Body    string
+}
+
+// Header represents a request header in declaration order.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// File represents a parsed .http file.
+//
+// Requests hold the text as written, with {{variable}} references unexpanded,
+// so that a reference that cannot be resolved only fails when its request is
+// selected. Lookup returns the selected request with its variables expanded.
+type File struct {
+	Variables map[string]string
+	Requests  []*Request
+	baseDir   string
+}
+
+// ParseFile reads and parses the .http file at path.
+// Body includes ("< ./payload.json") are resolved relative to the file.
+func ParseFile(path string) (*File, error) {
+	f, err := os.Open(path) //nolint:gosec // CLI argument
+	if err != nil {
+		return nil, i18n.Errorf("httpfile.read_error", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return parse(f, filepath.Dir(path))
+}
+
+// Parse parses a .http document from r.
+func Parse(r io.Reader) (*File, error) {
+	return parse(r, ".")
+}
+
+// parser holds the state of a single parse.
+type parser struct {
+	file  *File
+	block []string
+	name  string
+}
+
+func parse(r io.Reader, baseDir string) (*File, error) {
+	p := &parser{
+		file: &File{Variables: make(map[string]string), baseDir: baseDir},
+	}
+
+	var blocks [][]string
+	var names []string
+	var block []string
+	name := ""
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "###") {
+			blocks = append(blocks, block)
+			names = append(names, name)
+			block = nil
+			name = strings.TrimSpace(strings.TrimPrefix(line, "###"))
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("httpfile.read_error", err)
+	}
+	blocks = append(blocks, block)
+	names = append(names, name)
+
+	// 変数定義はファイル全体で有効なので、先に収集する
+	for _, b := range blocks {
+		for _, line := range b {
+			if m := definitionPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				p.file.Variables[m[1]] = strings.TrimSpace(m[2])
+			}
+		}
+	}
+
+	for i, b := range blocks {
+		p.block = b
+		p.name = names[i]
+		if err := p.parseBlock(); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.file, nil
+}
+
+//nolint:gocognit // .http の行ベースの文法をそのまま追うため
+func (p *parser) parseBlock() error {
+	name := p.name
+	lines := p.block
+	i := 0
+
+	// リクエスト行までのコメント・変数定義・空行を読み飛ばす
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || definitionPattern.MatchString(line) {
+			continue
+		}
+		if m := namePattern.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			continue
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		break
+	}
+	if i >= len(lines) {
+		return nil
+	}
+
+	requestLine := strings.TrimSpace(lines[i])
+	req := &Request{Name: name}
+	if m := requestLineRegexp.FindStringSubmatch(requestLine); m != nil {
+		req.Method = m[1]
+		req.URL = m[2]
+	} else if !strings.ContainsAny(requestLine, " \t") {
+		// メソッド省略時はGET
+		req.Method = "GET"
+		req.URL = requestLine
+	} else {
+		return i18n.Errorf("httpfile.invalid_request_line", requestLine)
+	}
+	i++
+
+	// 複数行に分割されたクエリ文字列
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		req.URL += line
+	}
+
+	// ヘッダー
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return i18n.Errorf("httpfile.invalid_header", line)
+		}
+		req.Headers = append(req.Headers, Header{Name: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1])})
+	}
+
+	// ボディ（末尾の空行とコメント行は除く）
+	bodyLines := lines[min(i, len(lines)):]
+	for len(bodyLines) > 0 {
+		last := strings.TrimSpace(bodyLines[len(bodyLines)-1])
+		if last == "" || strings.HasPrefix(last, "#") || strings.HasPrefix(last, "//") {
+			bodyLines = bodyLines[:len(bodyLines)-1]
+			continue
+		}
+		break
+	}
+	req.Body = strings.Join(bodyLines, "\n")
+
+	p.file.Requests = append(p.file.Requests, req)
+	return nil
+}
+
+// resolve returns a copy of req with its variables expanded and its body
+// include ("< ./payload.json") read. Dynamic variables such as {{$uuid}} are
+// generated here, so every run gets fresh values.
+func (f *File) resolve(req *Request) (*Request, error) {
+	resolved := &Request{Name: req.Name}
+
+	method, err := f.expand(req.Method)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Method = strings.ToUpper(method)
+
+	if resolved.URL, err = f.expand(req.URL); err != nil {
+		return nil, err
+	}
+
+	for _, h := range req.Headers {
+		value, err := f.expand(h.Value)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Headers = append(resolved.Headers, Header{Name: h.Name, Value: value})
+	}
+
+	if resolved.Body, err = f.readBody(req.Body); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func (f *File) readBody(body string) (string, error) {
+	line := strings.TrimSpace(body)
+	if !strings.Contains(body, "\n") && strings.HasPrefix(line, "<") {
+		filename := strings.TrimSpace(strings.TrimPrefix(line, "<"))
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(f.baseDir, filename)
+		}
+		content, err := os.ReadFile(filename) //nolint:gosec // referenced from the .http file
+		if err != nil {
+			return "", i18n.Errorf("httpfile.read_file_error", err)
+		}
+		return string(content), nil
+	}
+	return f.expand(body)
+}
+
+// expand replaces {{variable}} references. {{$requestId}} is kept as a placeholder
+// so that it can be resolved per request by the client.
+func (f *File) expand(s string) (string, error) {
+	return expandVariables(s, f.Variables, 0)
+}
+
+func expandVariables(s string, vars map[string]string, depth int) (string, error) {
+	if depth > 10 {
+		return "", i18n.Errorf("httpfile.variable_cycle", s)
+	}
+
+	var expandErr error
+	result := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if strings.HasPrefix(name, "$") {
+			value, ok := dynamicVariable(name)
+			if !ok {
+				if expandErr == nil {
+					expandErr = i18n.Errorf("httpfile.unsupported_dynamic_variable", name)
+				}
+				return match
+			}
+			return value
+		}
+		value, ok := vars[name]
+		if !ok {
+			if expandErr == nil {
+				expandErr = i18n.Errorf("httpfile.undefined_variable", name)
+			}
+			return match
+		}
+		expanded, err := expandVariables(value, vars, depth+1)
+		if err != nil && expandErr == nil {
+			expandErr = err
+		}
+		return expanded
+	})
+	return result, expandErr
+}
+
+func dynamicVariable(name string) (string, bool) {
+	switch name {
+	case "$requestId":
+		return config.RequestIDPlaceholder, true
+	case "$uuid", "$guid", "$random.uuid":
+		return requestid.Generate(), true
+	case "$timestamp":
+		return strconv.FormatInt(time.Now().Unix(), 10), true
+	}
+	return "", false
+}
+
+// Lookup returns the request selected by ref, which is either a request name or a
+// 1-based index, with its variables expanded. An empty ref selects the first request.
+func (f *File) Lookup(ref string) (*Request, error) {
+	if len(f.Requests) == 0 {
+		return nil, i18n.Errorf("httpfile.no_requests")
+	}
+	if ref == "" {
+		return f.resolve(f.Requests[0])
+	}
+	for _, req := range f.Requests {
+		if req.Name == ref {
+			return f.resolve(req)
+		}
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(f.Requests) {
+		return f.resolve(f.Requests[n-1])
+	}
+	return nil, i18n.Errorf("httpfile.request_not_found", ref)
+}
+
+// SplitRef splits "file.http#name" into the file path and request reference.
+func SplitRef(s string) (path, ref string) {
+	if i := strings.LastIndex(s, "#"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// Apply copies the request into cfg.
+//
+// The file variable @requestId sets a fixed request ID, and a header whose value is
+// {{$requestId}} names the request ID header; its value is then generated by conreq
+// according to --same-request-id like any other run. An error is returned when
+// @requestId cannot be expanded.
+func (f *File) Apply(req *Request, cfg *config.Config) error {
+	cfg.URL = req.URL
+	cfg.Method = req.Method
+	cfg.Body = req.Body
+
+	for _, h := range req.Headers {
+		if h.Value == config.RequestIDPlaceholder {
+			cfg.RequestIDHeader = h.Name
+			continue
+		}
+		cfg.Headers[h.Name] = h.Value
+	}
+
+	if id, ok := f.Variables[requestIDVariableName]; ok {
+		expanded, err := f.expand(id)
+		if err != nil {
+			return err
+		}
+		cfg.RequestID = expanded
+	}
+	return nil
+}
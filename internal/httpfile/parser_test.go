@@ -0,0 +1,318 @@
+package httpfile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/pkg/requestid"
+)
+
+const sample = `@host = https://api.example.com
+@token = secret
+
+### list users
+GET {{host}}/users
+    ?page=1
+    &limit=10
+Authorization: Bearer {{token}}
+
+###
+# @name createUser
+POST {{host}}/users HTTP/1.1
+Content-Type: application/json
+X-Correlation-ID: {{$requestId}}
+
+{
+  "name": "test user"
+}
+
+### health
+{{host}}/health
+`
+
+func TestParse(t *testing.T) {
+	file, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(file.Requests) != 3 {
+		t.Fatalf("Parse() returned %d requests, want 3", len(file.Requests))
+	}
+
+	tests := []struct {
+		name       string
+		wantMethod string
+		wantURL    string
+		wantBody   string
+		wantHeader int
+	}{
+		{"list users", "GET", "https://api.example.com/users?page=1&limit=10", "", 1},
+		{"createUser", "POST", "https://api.example.com/users", "{\n  \"name\": \"test user\"\n}", 2},
+		{"health", "GET", "https://api.example.com/health", "", 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := file.Lookup(strconv.Itoa(i + 1))
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if req.Name != tt.name {
+				t.Errorf("Name = %q, want %q", req.Name, tt.name)
+			}
+			if req.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", req.Method, tt.wantMethod)
+			}
+			if req.URL != tt.wantURL {
+				t.Errorf("URL = %q, want %q", req.URL, tt.wantURL)
+			}
+			if req.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", req.Body, tt.wantBody)
+			}
+			if len(req.Headers) != tt.wantHeader {
+				t.Errorf("len(Headers) = %d, want %d", len(req.Headers), tt.wantHeader)
+			}
+		})
+	}
+
+	req, err := file.Lookup("")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got := req.Headers[0].Value; got != "Bearer secret" {
+		t.Errorf("variable not expanded in header: %q", got)
+	}
+	if got := file.Requests[0].Headers[0].Value; got != "Bearer {{token}}" {
+		t.Errorf("Requests should keep the text as written: %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid header", "GET https://example.com\nInvalidHeader\n"},
+		{"invalid request line", "GET https://example.com extra words\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"undefined variable", "GET {{host}}/users\n"},
+		{"unsupported dynamic variable", "GET https://example.com/{{$unknown}}\n"},
+		{"circular variable", "@a = {{b}}\n@b = {{a}}\nGET https://example.com/{{a}}\n"},
+		{"missing body include", "POST https://example.com\n\n< ./missing.json\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if _, err := file.Lookup(""); err == nil {
+				t.Error("Lookup() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestLookupIgnoresOtherRequests checks that references which conreq cannot
+// resolve only fail when the request using them is selected.
+func TestLookupIgnoresOtherRequests(t *testing.T) {
+	input := `@host = https://api.example.com
+
+### login
+# @name login
+POST {{host}}/login
+
+### profile
+GET {{host}}/me
+Authorization: Bearer {{login.response.body.$.token}}
+X-Api-Key: {{$dotenv API_KEY}}
+
+### health
+GET {{host}}/health
+`
+	file, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	req, err := file.Lookup("health")
+	if err != nil {
+		t.Fatalf("Lookup(health) error = %v", err)
+	}
+	if req.URL != "https://api.example.com/health" {
+		t.Errorf("URL = %q", req.URL)
+	}
+	if _, err := file.Lookup("profile"); err == nil {
+		t.Error("Lookup(profile) error = nil, want an undefined variable error")
+	}
+}
+
+func TestLookupDynamicVariables(t *testing.T) {
+	file, err := Parse(strings.NewReader("POST https://example.com\n\n{\"id\": \"{{$uuid}}\"}\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var ids []string
+	for range 2 {
+		req, err := file.Lookup("")
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Body, `{"id": "`), `"}`)
+		if !requestid.IsValid(id) {
+			t.Errorf("{{$uuid}} was not replaced with a UUID: %q", req.Body)
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("{{$uuid}} was fixed at parse time: %q", ids[0])
+	}
+}
+
+func TestLookup(t *testing.T) {
+	file, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"", "list users", false},
+		{"createUser", "createUser", false},
+		{"3", "health", false},
+		{"4", "", true},
+		{"missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			req, err := file.Lookup(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+			if !tt.wantErr && req.Name != tt.want {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.ref, req.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitRef(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantPath string
+		wantRef  string
+	}{
+		{"api.http#createUser", "api.http", "createUser"},
+		{"api.http", "api.http", ""},
+		{"dir/api.http#2", "dir/api.http", "2"},
+	}
+
+	for _, tt := range tests {
+		path, ref := SplitRef(tt.input)
+		if path != tt.wantPath || ref != tt.wantRef {
+			t.Errorf("SplitRef(%q) = (%q, %q), want (%q, %q)", tt.input, path, ref, tt.wantPath, tt.wantRef)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	file, err := Parse(strings.NewReader("@requestId = fixed-{{env}}\n@env = dev\n" + sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	req, err := file.Lookup("createUser")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+
+	cfg := config.NewConfig()
+	if err := file.Apply(req, cfg); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if cfg.Method != "POST" || cfg.URL != "https://api.example.com/users" {
+		t.Errorf("Apply() method/url = %s %s", cfg.Method, cfg.URL)
+	}
+	if cfg.RequestIDHeader != "X-Correlation-ID" {
+		t.Errorf("RequestIDHeader = %q, want X-Correlation-ID", cfg.RequestIDHeader)
+	}
+	if _, ok := cfg.Headers["X-Correlation-ID"]; ok {
+		t.Error("request ID header should not be copied as a static header")
+	}
+	if cfg.RequestID != "fixed-dev" {
+		t.Errorf("RequestID = %q, want fixed-dev", cfg.RequestID)
+	}
+	if cfg.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q", cfg.Headers["Content-Type"])
+	}
+}
+
+func TestApplyRequestIDError(t *testing.T) {
+	file, err := Parse(strings.NewReader("@requestId = fixed-{{tenant}}\n" + sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	req, err := file.Lookup("createUser")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+
+	// 展開できない@requestIdは生成されたIDで置き換えずにエラーにする
+	cfg := config.NewConfig()
+	err = file.Apply(req, cfg)
+	if err == nil || !strings.Contains(err.Error(), "tenant") {
+		t.Errorf("Apply() error = %v, want undefined variable error", err)
+	}
+	if cfg.RequestID != "" {
+		t.Errorf("RequestID = %q, want unset", cfg.RequestID)
+	}
+}
+
+func TestParseFileBodyInclude(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "payload.json"), []byte(`{"a":1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "api.http")
+	if err := os.WriteFile(path, []byte("POST https://example.com\n\n< ./payload.json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	req, err := file.Lookup("")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got := req.Body; got != `{"a":1}` {
+		t.Errorf("Body = %q, want included file content", got)
+	}
+}
@@ -0,0 +1,205 @@
+package i18n
+
+// en is the English message catalog.
+var en = map[string]string{
+	"i18n.unsupported_language": "unsupported language: %s (use one of %s)",
+
+	// コマンド
+	"cmd.short": "Send concurrent HTTP requests to the same endpoint",
+	"cmd.long": `conreq sends multiple concurrent HTTP requests to the same API endpoint
+to verify how the API behaves.`,
+	"cmd.schema_short": "Print the JSON Schema of the JSON output",
+	"cmd.schema_long": `Prints the JSON Schema (draft 2020-12) of the results written by --json.
+The schema_version field of the output is the version of this schema (currently %s).`,
+	"cmd.format_conflict":       "--format and --format-file cannot be used together",
+	"cmd.template_file_error":   "failed to read the template file: %w",
+	"cmd.multiple_formats":      "specify only one output format (--json, --har, --junit, --csv, --tsv, --html, --format); use --out to write several formats",
+	"cmd.invalid_timeout":       "invalid timeout: %w",
+	"cmd.invalid_phase_timeout": "invalid --%s: %w",
+	"cmd.invalid_delay":         "invalid delay: %w",
+	"cmd.read_file_error":       "failed to read the file: %w",
+	"cmd.events_file_error":     "failed to create the events file: %w",
+	"cmd.events_error":          "failed to write an event: %w",
+	"cmd.key_input_error":       "failed to read a key: %w",
+	"cmd.final_results":         "Final Results:",
+
+	// ヘルプの見出し
+	"usage.usage":               "Usage:",
+	"usage.aliases":             "Aliases:",
+	"usage.examples":            "Examples:",
+	"usage.available_commands":  "Available Commands:",
+	"usage.additional_commands": "Additional Commands:",
+	"usage.flags":               "Flags:",
+	"usage.global_flags":        "Global Flags:",
+	"usage.help_topics":         "Additional help topics:",
+	"usage.more_info":           `Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+
+	// フラグ
+	"flag.method":                  "HTTP method (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS)",
+	"flag.concurrent":              "number of concurrent requests (1-5)",
+	"flag.header":                  "custom header (e.g. \"Content-Type: application/json\")",
+	"flag.data":                    "request body (@file reads it from a file)",
+	"flag.request_id":              "custom Request ID value",
+	"flag.same_request_id":         "use the same Request ID for all requests",
+	"flag.request_id_header":       "Request ID header name",
+	"flag.request_id_format":       "Request ID format (%s; pass an argument as \"format:value\", e.g. \"sequential:order\")",
+	"flag.id_header":               "additional generated header (repeatable), e.g. \"Idempotency-Key,shared\", \"X-Trace-Key,format=ulid\", \"X-Tenant,value=acme\"",
+	"flag.idempotency":             "idempotency key verification (send a shared key and compare the responses for PASS/FAIL)",
+	"flag.idempotency_header":      "idempotency key header name",
+	"flag.conflict_status":         "conflict status codes accepted by the idempotency verification",
+	"flag.diff":                    "show the differences (status, headers, body) from the reference response as a unified diff",
+	"flag.diff_reference":          "number of the reference response for the diff",
+	"flag.diff_header":             "response header compared by the diff (default: all headers)",
+	"flag.ignore_header":           "response header ignored by the diff (repeatable; Date is always ignored)",
+	"flag.ignore_json_path":        "JSONPath ignored when comparing responses (repeatable), e.g. \"$.created_at\"",
+	"flag.select":                  "JSONPath shown instead of the body (repeatable), e.g. \"$.order.status\"",
+	"flag.redact_header":           "header whose values are masked in the output (repeatable; Authorization, Cookie, Set-Cookie and API keys are masked by default)",
+	"flag.redact_json_path":        "JSONPath of request and response bodies masked in the output (repeatable), e.g. \"$.access_token\"",
+	"flag.no_redact":               "disable masking of secrets",
+	"flag.verify_request_id":       "verify that responses echo the Request ID",
+	"flag.verify_request_id_body":  "also look for the Request ID in the response body when no header echoes it (implies --verify-request-id)",
+	"flag.trace":                   "send trace context headers (w3c, b3, b3multi)",
+	"flag.tracestate":              "W3C tracestate header value (with --trace w3c)",
+	"flag.delay":                   "delay between requests (e.g. \"100ms\", \"1s\")",
+	"flag.timeout":                 "timeout (e.g. \"10s\", \"30s\")",
+	"flag.connect_timeout":         "TCP connect timeout (default: 30s)",
+	"flag.tls_timeout":             "TLS handshake timeout (default: 10s)",
+	"flag.response_header_timeout": "timeout for the response headers after the request is sent",
+	"flag.body_idle_timeout":       "timeout when no response body data arrives",
+	"flag.no_body":                 "hide response bodies (ignored by the JSON output)",
+	"flag.show_headers":            "show response headers and trailers (text output)",
+	"flag.timeline":                "show the duration and overlap of the requests as a timeline (text output)",
+	"flag.color":                   "colored output (auto, always, never); auto colors terminal output only and is disabled by NO_COLOR",
+	"flag.json":                    "output as JSON",
+	"flag.har":                     "output as HAR 1.2 (viewable in browser developer tools and HAR viewers)",
+	"flag.junit":                   "output as JUnit XML (for CI)",
+	"flag.csv":                     "output as CSV (one row per response)",
+	"flag.tsv":                     "output as TSV (one row per response)",
+	"flag.html":                    "output as a single-file HTML report with a timeline",
+	"flag.format":                  "output with a Go template, e.g. '{{range .Results}}{{println .Index .Status (duration .Duration)}}{{end}}'",
+	"flag.format_file":             "output with a Go template read from a file",
+	"flag.columns":                 "comma-separated CSV/TSV columns (%s)",
+	"flag.no_header":               "omit the CSV/TSV header row",
+	"flag.output":                  "write the results to a file",
+	"flag.out":                     "add an output as \"format:path\" (repeatable; an empty path or - writes to stdout), e.g. \"json:result.json\", \"junit:report.xml\"",
+	"flag.version":                 "show version information",
+	"flag.stream":                  "show progress in real time (NDJSON events go to stdout when stdout is unused or JSON)",
+	"flag.interactive":             "after the --stream dashboard finishes, show request details by pressing keys",
+	"flag.no_dashboard":            "with --stream, print progress line by line instead of the dashboard even on a terminal",
+	"flag.events_file":             "write progress as NDJSON events to a file",
+	"flag.from_http":               "read the request from a .http file (e.g. \"api.http#createUser\")",
+	"flag.lang":                    "message language (ja, en); defaults to LC_ALL, LC_MESSAGES or LANG",
+	"flag.help":                    "help for %s",
+
+	// 設定の検証
+	"config.url_required":                 "no URL specified",
+	"config.invalid_method":               "invalid HTTP method: %s",
+	"config.invalid_count":                "the number of concurrent requests must be between 1 and 5: %d",
+	"config.invalid_timeout":              "the timeout must be positive: %s",
+	"config.invalid_delay":                "the delay must not be negative: %s",
+	"config.redact_conflict":              "--no-redact cannot be used with --redact-header or --redact-json-path",
+	"config.invalid_diff_reference":       "the reference response for the diff must be between 1 and %d: %d",
+	"config.idempotency_count":            "idempotency verification needs at least 2 concurrent requests: %d",
+	"config.invalid_color":                "invalid color mode: %s (use one of %s)",
+	"config.invalid_trace":                "invalid trace propagation format: %s (use one of %s)",
+	"config.negative_phase_timeout":       "the %s must not be negative: %s",
+	"config.connect_timeout":              "connect timeout",
+	"config.tls_timeout":                  "TLS handshake timeout",
+	"config.response_header_timeout":      "response header timeout",
+	"config.body_idle_timeout":            "body idle timeout",
+	"config.invalid_header":               "invalid header: %s",
+	"config.invalid_correlation_header":   "invalid correlation header: %s",
+	"config.invalid_correlation_option":   "invalid correlation header option: %s (%s)",
+	"config.duplicate_correlation_header": "duplicate correlation header: %s",
+	"config.correlation_header":           "correlation header %s: %w",
+	"config.invalid_output":               "invalid output: %s (use format:path)",
+	"config.multiple_stdout":              "only one output can be written to stdout",
+	"config.duplicate_output":             "duplicate output file: %s",
+
+	// .httpファイル
+	"httpfile.read_error":                   "failed to read the .http file: %w",
+	"httpfile.invalid_request_line":         "invalid request line: %s",
+	"httpfile.invalid_header":               "invalid header: %s",
+	"httpfile.read_file_error":              "failed to read the file: %w",
+	"httpfile.variable_cycle":               "variables reference each other in a cycle: %s",
+	"httpfile.unsupported_dynamic_variable": "unsupported dynamic variable: %s",
+	"httpfile.undefined_variable":           "undefined variable: %s",
+	"httpfile.no_requests":                  "the .http file defines no requests",
+	"httpfile.request_not_found":            "request not found in the .http file: %s",
+
+	// JSONPath
+	"jsonpath.invalid":         "invalid JSONPath: %s",
+	"jsonpath.missing_member":  "invalid JSONPath: %s (.. must be followed by a member name)",
+	"jsonpath.missing_bracket": "invalid JSONPath: %s (missing ])",
+	"jsonpath.invalid_cause":   "invalid JSONPath: %s (%w)",
+	"jsonpath.invalid_index":   "invalid index: %s",
+
+	// Request ID
+	"requestid.no_argument":   "request ID format %q takes no argument: %q",
+	"requestid.nanoid_length": "the nanoid length must be between 1 and 255: %q",
+	"requestid.invalid_name":  "invalid request ID format name: %q",
+	"requestid.nil_factory":   "the Factory of request ID format %q is nil",
+	"requestid.duplicate":     "request ID format %q is already registered",
+	"requestid.unsupported":   "unsupported request ID format: %q (available: %s)",
+
+	// HTTPクライアント・ターミナル
+	"client.read_body":             "failed to read the response body: %w",
+	"client.create_request":        "failed to create the request: %w",
+	"client.phase_timeout":         "%s timeout (%s) exceeded: %v",
+	"client.default_phase_timeout": "%s timeout (default %s) exceeded: %v",
+	"client.total_timeout":         "total timeout (%s) exceeded during %s: %v",
+	"terminal.get_attr":            "cannot get the terminal settings: %w",
+	"terminal.set_attr":            "cannot change the terminal settings: %w",
+	"terminal.unsupported_input":   "key input is not supported on this platform",
+
+	// 出力
+	"output.xml_unclosed":        "unclosed XML element",
+	"output.template_parse":      "failed to parse the template: %w",
+	"output.template_execute":    "failed to execute the template: %w",
+	"output.template_required":   "the template output needs --format or --format-file",
+	"output.invalid_format_name": "invalid output format name: %q",
+	"output.nil_factory":         "the Factory of output format %q is nil",
+	"output.duplicate_format":    "output format %q is already registered",
+	"output.unsupported_format":  "unsupported output format: %q (available: %s)",
+	"output.create_file":         "failed to create the output file: %w",
+	"output.write_file":          "failed to write the output file: %w",
+	"output.unsupported_column":  "unsupported column: %s (use one of %s)",
+
+	// テキストレポートの見出しとメッセージ
+	"report.request_summary":             "=== Request Summary ===",
+	"report.results":                     "=== Results ===",
+	"report.summary":                     "=== Summary ===",
+	"report.status_breakdown":            "=== Status Code Breakdown ===",
+	"report.echo_verification":           "=== %s Echo Verification ===",
+	"report.all_echoed":                  "All request IDs were echoed back",
+	"report.consistency":                 "=== Consistency Diff ===",
+	"report.all_consistent":              "All responses are consistent",
+	"report.idempotency":                 "=== Idempotency Verification ===",
+	"report.idempotency_reference":       "Reference: [%d]",
+	"report.idempotency_replays":         "Replays: %s",
+	"report.idempotency_conflicts":       "Conflicts: %s",
+	"report.idempotency_result":          "Result: %s",
+	"report.violation_request_failed":    "request failed: %v",
+	"report.violation_status_differs":    "status %d differs from reference #%d (%d)",
+	"report.violation_body_differs":      "body differs from reference #%d",
+	"report.violation_unexpected_status": "unexpected status %d (neither a replay nor a conflict status)",
+	"report.violation_no_success":        "no request succeeded with a 2xx status",
+	"report.clusters":                    "=== Response Clusters ===",
+	"report.cluster_representative":      "-- Cluster %s: representative [%d] --",
+	"report.latency":                     "=== Latency ===",
+	"report.timeline":                    "=== Timeline ===",
+	"report.overlap_none":                "Overlap: none (not all requests were in flight at the same time)",
+	"report.overlap_all":                 "Overlap: all %d requests in flight for %s (+%dms to +%dms)",
+	"report.body_omitted":                "[Body omitted]",
+	"report.body_not_json":               "[Body is not JSON]",
+	"report.trailers":                    "-- Trailers --",
+
+	// 進行状況
+	"progress.start":     "🚀 Starting %d concurrent requests at %s",
+	"progress.finished":  "🎉 All requests completed in %s at %s",
+	"dashboard.running":  "Running %d requests: %d/%d done, %s elapsed",
+	"dashboard.finished": "All %d requests completed in %s",
+	"dashboard.more":     "... and %d more requests",
+	"dashboard.prompt":   "Press 1-%d to show a request, q to quit",
+	"dashboard.request":  "=== Request [%d] ===",
+}
@@ -0,0 +1,144 @@
+// Package i18n provides the message catalog for the command line interface:
+// flag descriptions, error messages and the headings of text reports.
+//
+// Machine-readable output (JSON, HAR, JUnit, CSV, events) and the field
+// labels of text reports are not translated, so that scripts parsing them
+// work regardless of the language.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Supported languages.
+const (
+	Japanese = "ja"
+	English  = "en"
+)
+
+// DefaultLanguage is used for help, flag descriptions and error messages
+// when neither the locale nor --lang selects a language.
+const DefaultLanguage = Japanese
+
+// DefaultReportLanguage is used for the headings of text reports and
+// progress displays when no language is selected, so that scripts reading
+// them keep working on hosts without a locale.
+const DefaultReportLanguage = English
+
+// reportKeys are the prefixes of the message IDs that DefaultReportLanguage
+// applies to.
+var reportKeys = []string{"report.", "progress.", "dashboard.", "cmd.final_results", "usage.", "flag.help"}
+
+// Languages lists the supported languages.
+var Languages = []string{Japanese, English}
+
+// catalogs maps each language to its messages, keyed by message ID.
+// Messages are fmt format strings.
+var catalogs = map[string]map[string]string{
+	Japanese: ja,
+	English:  en,
+}
+
+var (
+	mu      sync.RWMutex
+	current string // ""は言語が選択されていない状態
+)
+
+// Detect returns the language selected by the LC_ALL, LC_MESSAGES and LANG
+// environment variables, in that order of precedence. Locales starting with
+// "en" select English and locales starting with "ja" select Japanese; any
+// other value, including "C" and "POSIX", and an unset locale return "".
+func Detect(getenv func(string) string) string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := getenv(name); locale != "" {
+			lang, _ := Parse(locale)
+			return lang
+		}
+	}
+	return ""
+}
+
+// Parse returns the supported language of a language tag or locale such as
+// "en", "en_US.UTF-8" or "ja-JP".
+func Parse(locale string) (string, bool) {
+	tag := strings.ToLower(locale)
+	for _, lang := range Languages {
+		if tag == lang || strings.HasPrefix(tag, lang+"_") || strings.HasPrefix(tag, lang+"-") || strings.HasPrefix(tag, lang+".") {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// SetLanguage selects the language of the messages. An empty lang clears the
+// selection, so that DefaultLanguage and DefaultReportLanguage apply.
+func SetLanguage(lang string) error {
+	if lang == "" {
+		mu.Lock()
+		defer mu.Unlock()
+		current = ""
+		return nil
+	}
+
+	parsed, ok := Parse(lang)
+	if !ok {
+		return Errorf("i18n.unsupported_language", lang, strings.Join(Languages, ", "))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = parsed
+	return nil
+}
+
+// Language returns the selected language, or "" when none is selected.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message for key in the selected language, formatted with
+// args. Without a selected language, report headings are in
+// DefaultReportLanguage and other messages in DefaultLanguage. A message
+// missing from the selected language falls back to DefaultLanguage, and an
+// unknown key is returned as is.
+func T(key string, args ...any) string {
+	format := message(key)
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Errorf returns an error with the message for key formatted with args.
+// Like fmt.Errorf, a %w verb in the message wraps the corresponding error.
+func Errorf(key string, args ...any) error {
+	return fmt.Errorf(message(key), args...)
+}
+
+func message(key string) string {
+	lang := Language()
+	if lang == "" {
+		lang = defaultLanguage(key)
+	}
+	if format, ok := catalogs[lang][key]; ok {
+		return format
+	}
+	if format, ok := catalogs[DefaultLanguage][key]; ok {
+		return format
+	}
+	return key
+}
+
+// defaultLanguage returns the language of key when no language is selected.
+func defaultLanguage(key string) string {
+	for _, prefix := range reportKeys {
+		if strings.HasPrefix(key, prefix) {
+			return DefaultReportLanguage
+		}
+	}
+	return DefaultLanguage
+}
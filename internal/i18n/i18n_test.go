@@ -0,0 +1,218 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// verbPattern matches the fmt verbs of a message.
+var verbPattern = regexp.MustCompile(`%[-+# 0]*(\[\d+\])?[\d*]*(\.[\d*]+)?[a-zA-Z%]`)
+
+func setLanguage(t *testing.T, lang string) {
+	t.Helper()
+	previous := Language()
+	if err := SetLanguage(lang); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetLanguage(previous) })
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, lang := range Languages {
+		catalog, ok := catalogs[lang]
+		if !ok {
+			t.Fatalf("no catalog for %s", lang)
+		}
+		for key, message := range catalog {
+			if message == "" {
+				t.Errorf("%s: %s is empty", lang, key)
+			}
+		}
+		for _, other := range Languages {
+			for key := range catalogs[other] {
+				if _, ok := catalog[key]; !ok {
+					t.Errorf("%s: %s is missing (defined in %s)", lang, key, other)
+				}
+			}
+		}
+	}
+}
+
+// TestCatalogVerbs checks that the translations of a message take the same
+// arguments in the same order.
+func TestCatalogVerbs(t *testing.T) {
+	for key, message := range catalogs[DefaultLanguage] {
+		want := verbPattern.FindAllString(message, -1)
+		for _, lang := range Languages {
+			if got := verbPattern.FindAllString(catalogs[lang][key], -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %s has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+	}
+}
+
+// TestCatalogHasUsedKeys checks that every key passed to T or Errorf in the
+// module is defined in the catalogs.
+func TestCatalogHasUsedKeys(t *testing.T) {
+	call := regexp.MustCompile(`i18n\.(?:T|Errorf)\("([^"]+)"`)
+	root := filepath.Join("..", "..")
+	used := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != root {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range call.FindAllStringSubmatch(string(src), -1) {
+			used++
+			if _, ok := catalogs[DefaultLanguage][m[1]]; !ok {
+				t.Errorf("%s: %s is not in the catalog", path, m[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used == 0 {
+		t.Error("no message keys found in the module")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+		ok     bool
+	}{
+		{"en", English, true},
+		{"en_US.UTF-8", English, true},
+		{"EN-gb", English, true},
+		{"ja", Japanese, true},
+		{"ja_JP.UTF-8", Japanese, true},
+		{"ja-JP", Japanese, true},
+		{"C", "", false},
+		{"POSIX", "", false},
+		{"eng", "", false},
+		{"fr_FR.UTF-8", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			got, ok := Parse(tt.locale)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.locale, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"unset", nil, ""},
+		{"LANG", map[string]string{"LANG": "en_US.UTF-8"}, English},
+		{"LC_MESSAGES over LANG", map[string]string{"LC_MESSAGES": "en_US.UTF-8", "LANG": "ja_JP.UTF-8"}, English},
+		{"LC_ALL over LC_MESSAGES", map[string]string{"LC_ALL": "ja_JP.UTF-8", "LC_MESSAGES": "en_US.UTF-8"}, Japanese},
+		{"C locale", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, ""},
+		{"unsupported", map[string]string{"LANG": "fr_FR.UTF-8"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+			if got := Detect(getenv); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	setLanguage(t, "en_US.UTF-8")
+	if got := Language(); got != English {
+		t.Errorf("Language() = %q, want %q", got, English)
+	}
+
+	err := SetLanguage("fr")
+	if err == nil || !strings.Contains(err.Error(), "fr") {
+		t.Errorf("SetLanguage(fr) error = %v, want an unsupported language error", err)
+	}
+	if got := Language(); got != English {
+		t.Errorf("Language() = %q after a failed SetLanguage, want %q", got, English)
+	}
+}
+
+func TestT(t *testing.T) {
+	setLanguage(t, English)
+	if got, want := T("config.invalid_count", 9), "the number of concurrent requests must be between 1 and 5: 9"; got != want {
+		t.Errorf("T() = %q, want %q", got, want)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(unknown) = %q, want the key", got)
+	}
+
+	setLanguage(t, Japanese)
+	if got, want := T("config.invalid_count", 9), "同時リクエスト数は1-5の範囲で指定してください: 9"; got != want {
+		t.Errorf("T() = %q, want %q", got, want)
+	}
+}
+
+func TestTWithoutLanguage(t *testing.T) {
+	setLanguage(t, "")
+	if got := Language(); got != "" {
+		t.Errorf("Language() = %q, want none", got)
+	}
+
+	// 言語が未選択の場合、レポートの見出しは英語、それ以外は日本語のまま
+	tests := []struct {
+		key  string
+		args []any
+		want string
+	}{
+		{"report.summary", nil, "=== Summary ==="},
+		{"progress.finished", []any{"1.00s", "12:00:00"}, "🎉 All requests completed in 1.00s at 12:00:00"},
+		{"dashboard.prompt", []any{3}, "Press 1-3 to show a request, q to quit"},
+		{"cmd.final_results", nil, "Final Results:"},
+		{"usage.flags", nil, "Flags:"},
+		{"flag.help", []any{"conreq"}, "help for conreq"},
+		{"flag.concurrent", nil, "同時リクエスト数 (1-5)"},
+		{"config.invalid_count", []any{9}, "同時リクエスト数は1-5の範囲で指定してください: 9"},
+		{"config.url_required", nil, "URLが指定されていません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := T(tt.key, tt.args...); got != tt.want {
+				t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	setLanguage(t, English)
+	cause := errors.New("unexpected EOF")
+	err := Errorf("output.template_parse", cause)
+	if !errors.Is(err, cause) {
+		t.Errorf("Errorf() = %v, want it to wrap the cause", err)
+	}
+	if got, want := err.Error(), "failed to parse the template: unexpected EOF"; got != want {
+		t.Errorf("Errorf() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+package i18n
+
+// ja is the Japanese message catalog.
+var ja = map[string]string{
+	"i18n.unsupported_language": "未対応の言語: %s (%s のいずれかを指定してください)",
+
+	// コマンド
+	"cmd.short": "同一エンドポイントへの並行HTTPリクエストツール",
+	"cmd.long": `conreqは、同一のAPIエンドポイントに対して複数の並行HTTPリクエストを送信し、
+APIの挙動を検証するためのツールです。`,
+	"cmd.schema_short": "JSON出力のJSON Schemaを表示",
+	"cmd.schema_long": `--jsonで出力される結果のJSON Schema（draft 2020-12）を表示します。
+出力のschema_versionフィールドは、このスキーマのバージョン（現在は%s）を示します。`,
+	"cmd.format_conflict":       "--formatと--format-fileは同時に指定できません",
+	"cmd.template_file_error":   "テンプレートファイル読み込みエラー: %w",
+	"cmd.multiple_formats":      "出力形式は1つだけ指定してください (--json, --har, --junit, --csv, --tsv, --html, --format)。複数の形式で出力する場合は--outを使用してください",
+	"cmd.invalid_timeout":       "無効なタイムアウト形式: %w",
+	"cmd.invalid_phase_timeout": "無効な--%s形式: %w",
+	"cmd.invalid_delay":         "無効な遅延時間形式: %w",
+	"cmd.read_file_error":       "ファイル読み込みエラー: %w",
+	"cmd.events_file_error":     "イベントファイル作成エラー: %w",
+	"cmd.events_error":          "イベント出力エラー: %w",
+	"cmd.key_input_error":       "キー入力の読み取りエラー: %w",
+	"cmd.final_results":         "最終結果:",
+
+	// ヘルプの見出し
+	"usage.usage":               "使い方:",
+	"usage.aliases":             "別名:",
+	"usage.examples":            "例:",
+	"usage.available_commands":  "コマンド:",
+	"usage.additional_commands": "その他のコマンド:",
+	"usage.flags":               "フラグ:",
+	"usage.global_flags":        "グローバルフラグ:",
+	"usage.help_topics":         "その他のヘルプ:",
+	"usage.more_info":           `各コマンドの詳細は "{{.CommandPath}} [command] --help" で表示できます。`,
+
+	// フラグ
+	"flag.method":                  "HTTPメソッド (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS)",
+	"flag.concurrent":              "同時リクエスト数 (1-5)",
+	"flag.header":                  "カスタムヘッダー (例: \"Content-Type: application/json\")",
+	"flag.data":                    "リクエストボディ (@でファイル指定可)",
+	"flag.request_id":              "カスタムRequest ID値を指定",
+	"flag.same_request_id":         "全リクエストで同一のRequest IDを使用",
+	"flag.request_id_header":       "Request IDヘッダー名",
+	"flag.request_id_format":       "Request IDの生成形式 (%s、引数は\"形式:値\"で指定 例: \"sequential:order\")",
+	"flag.id_header":               "追加で生成するヘッダー（複数指定可） 例: \"Idempotency-Key,shared\", \"X-Trace-Key,format=ulid\", \"X-Tenant,value=acme\"",
+	"flag.idempotency":             "冪等性キー検証モード（共有キーを送信しレスポンスを比較してPASS/FAILを判定）",
+	"flag.idempotency_header":      "冪等性キーのヘッダー名",
+	"flag.conflict_status":         "冪等性検証で許容する競合ステータスコード",
+	"flag.diff":                    "基準レスポンスとの差分（ステータス・ヘッダー・ボディ）をunified diffで表示",
+	"flag.diff_reference":          "差分の基準とするレスポンス番号",
+	"flag.diff_header":             "差分で比較するレスポンスヘッダー（未指定時は全ヘッダー）",
+	"flag.ignore_header":           "差分で無視するレスポンスヘッダー（複数指定可、Dateは常に無視）",
+	"flag.ignore_json_path":        "レスポンス比較時に無視するJSONPath（複数指定可） 例: \"$.created_at\"",
+	"flag.select":                  "ボディの代わりに表示するJSONPath（複数指定可） 例: \"$.order.status\"",
+	"flag.redact_header":           "出力時に値をマスクするヘッダー（複数指定可、Authorization・Cookie・Set-Cookie・APIキー類は既定でマスク）",
+	"flag.redact_json_path":        "出力時に値をマスクするリクエスト・レスポンスボディのJSONPath（複数指定可） 例: \"$.access_token\"",
+	"flag.no_redact":               "秘密情報のマスクを無効にする",
+	"flag.verify_request_id":       "レスポンスでRequest IDがエコーされたか検証",
+	"flag.verify_request_id_body":  "ヘッダーに無い場合はレスポンスボディ内のRequest IDも検証（--verify-request-idを含む）",
+	"flag.trace":                   "トレースコンテキストヘッダーを送信 (w3c, b3, b3multi)",
+	"flag.tracestate":              "W3C tracestateヘッダー値 (--trace w3c時)",
+	"flag.delay":                   "リクエスト間の遅延時間 (例: \"100ms\", \"1s\")",
+	"flag.timeout":                 "タイムアウト時間 (例: \"10s\", \"30s\")",
+	"flag.connect_timeout":         "TCP接続のタイムアウト時間 (既定: 30s)",
+	"flag.tls_timeout":             "TLSハンドシェイクのタイムアウト時間 (既定: 10s)",
+	"flag.response_header_timeout": "リクエスト送信後レスポンスヘッダー受信までのタイムアウト時間",
+	"flag.body_idle_timeout":       "レスポンスボディ受信が途絶えた場合のタイムアウト時間",
+	"flag.no_body":                 "レスポンスボディを非表示（JSON出力時は無視）",
+	"flag.show_headers":            "レスポンスヘッダーとトレーラーを表示（テキスト出力時）",
+	"flag.timeline":                "リクエストの実行期間と重なりをタイムラインで表示（テキスト出力時）",
+	"flag.color":                   "色付き表示 (auto, always, never)。autoはターミナル出力時のみ色付けし、NO_COLORが設定されていれば無効",
+	"flag.json":                    "JSON形式で出力",
+	"flag.har":                     "HAR 1.2形式で出力（ブラウザの開発者ツールやHARビューアで表示可能）",
+	"flag.junit":                   "JUnit XML形式で出力（CI向け）",
+	"flag.csv":                     "CSV形式で出力（1レスポンス1行）",
+	"flag.tsv":                     "TSV形式で出力（1レスポンス1行）",
+	"flag.html":                    "HTMLレポート形式で出力（タイムライン付き、単一ファイル）",
+	"flag.format":                  "Goテンプレートで出力 例: '{{range .Results}}{{println .Index .Status (duration .Duration)}}{{end}}'",
+	"flag.format_file":             "Goテンプレートをファイルから読み込んで出力",
+	"flag.columns":                 "CSV/TSVの出力列をカンマ区切りで指定 (%s)",
+	"flag.no_header":               "CSV/TSVのヘッダー行を出力しない",
+	"flag.output":                  "結果をファイルに出力",
+	"flag.out":                     "結果の出力先を\"形式:パス\"で追加（複数指定可、パス省略または-で標準出力） 例: \"json:result.json\", \"junit:report.xml\"",
+	"flag.version":                 "バージョン情報を表示",
+	"flag.stream":                  "リアルタイムで進行状況を表示（標準出力がJSON出力か未使用の場合はNDJSONイベントを標準出力に出力）",
+	"flag.interactive":             "--streamのダッシュボード完了後にキー入力でリクエストの詳細を表示",
+	"flag.no_dashboard":            "--stream時にターミナルでもダッシュボードを使わず進行状況を1行ずつ表示",
+	"flag.events_file":             "進行状況をNDJSONイベントとしてファイルに出力",
+	"flag.from_http":               ".httpファイルからリクエストを読み込み (例: \"api.http#createUser\")",
+	"flag.lang":                    "メッセージの言語 (ja, en)。未指定時はLC_ALL・LC_MESSAGES・LANGから判定",
+	"flag.help":                    "%sのヘルプを表示",
+
+	// 設定の検証
+	"config.url_required":                 "URLが指定されていません",
+	"config.invalid_method":               "無効なHTTPメソッド: %s",
+	"config.invalid_count":                "同時リクエスト数は1-5の範囲で指定してください: %d",
+	"config.invalid_timeout":              "タイムアウトは正の値を指定してください: %s",
+	"config.invalid_delay":                "遅延時間は0以上の値を指定してください: %s",
+	"config.redact_conflict":              "--no-redactと--redact-header、--redact-json-pathは同時に指定できません",
+	"config.invalid_diff_reference":       "差分の基準レスポンスは1-%dの範囲で指定してください: %d",
+	"config.idempotency_count":            "冪等性検証には2以上の同時リクエスト数を指定してください: %d",
+	"config.invalid_color":                "無効なカラー設定: %s (%s のいずれかを指定してください)",
+	"config.invalid_trace":                "無効なトレース伝播形式: %s (%s のいずれかを指定してください)",
+	"config.negative_phase_timeout":       "%sは0以上の値を指定してください: %s",
+	"config.connect_timeout":              "接続タイムアウト",
+	"config.tls_timeout":                  "TLSハンドシェイクタイムアウト",
+	"config.response_header_timeout":      "レスポンスヘッダータイムアウト",
+	"config.body_idle_timeout":            "ボディ受信アイドルタイムアウト",
+	"config.invalid_header":               "無効なヘッダー形式: %s",
+	"config.invalid_correlation_header":   "無効な相関ヘッダー指定: %s",
+	"config.invalid_correlation_option":   "無効な相関ヘッダーオプション: %s (%s)",
+	"config.duplicate_correlation_header": "相関ヘッダーが重複しています: %s",
+	"config.correlation_header":           "相関ヘッダー %s: %w",
+	"config.invalid_output":               "無効な出力先の指定: %s (形式:パス の形式で指定してください)",
+	"config.multiple_stdout":              "標準出力に出力できる形式は1つだけです",
+	"config.duplicate_output":             "出力ファイルが重複しています: %s",
+
+	// .httpファイル
+	"httpfile.read_error":                   ".httpファイル読み込みエラー: %w",
+	"httpfile.invalid_request_line":         "無効なリクエスト行: %s",
+	"httpfile.invalid_header":               "無効なヘッダー形式: %s",
+	"httpfile.read_file_error":              "ファイル読み込みエラー: %w",
+	"httpfile.variable_cycle":               "変数の参照が循環しています: %s",
+	"httpfile.unsupported_dynamic_variable": "未対応の動的変数: %s",
+	"httpfile.undefined_variable":           "未定義の変数: %s",
+	"httpfile.no_requests":                  ".httpファイルにリクエストが定義されていません",
+	"httpfile.request_not_found":            ".httpファイルにリクエストが見つかりません: %s",
+
+	// JSONPath
+	"jsonpath.invalid":         "無効なJSONPath: %s",
+	"jsonpath.missing_member":  "無効なJSONPath: %s (..の後にメンバー名が必要です)",
+	"jsonpath.missing_bracket": "無効なJSONPath: %s (]がありません)",
+	"jsonpath.invalid_cause":   "無効なJSONPath: %s (%w)",
+	"jsonpath.invalid_index":   "インデックスが不正です: %s",
+
+	// Request ID
+	"requestid.no_argument":   "引数を取らないRequest ID形式に引数が指定されています: %q (%q)",
+	"requestid.nanoid_length": "nanoidの長さは1-255で指定してください: %q",
+	"requestid.invalid_name":  "無効なRequest ID形式名: %q",
+	"requestid.nil_factory":   "FactoryがnilのRequest ID形式です: %q",
+	"requestid.duplicate":     "登録済みのRequest ID形式です: %q",
+	"requestid.unsupported":   "未対応のRequest ID形式: %q (利用可能: %s)",
+
+	// HTTPクライアント・ターミナル
+	"client.read_body":             "レスポンスボディの読み取りエラー: %w",
+	"client.create_request":        "リクエスト作成エラー: %w",
+	"client.phase_timeout":         "%sタイムアウト (%s) を超過しました: %v",
+	"client.default_phase_timeout": "%sタイムアウト (既定値 %s) を超過しました: %v",
+	"client.total_timeout":         "全体のタイムアウト (%s) を%sの処理中に超過しました: %v",
+	"terminal.get_attr":            "ターミナルの設定を取得できません: %w",
+	"terminal.set_attr":            "ターミナルの設定を変更できません: %w",
+	"terminal.unsupported_input":   "このプラットフォームではキー入力に対応していません",
+
+	// 出力
+	"output.xml_unclosed":        "XMLの要素が閉じられていません",
+	"output.template_parse":      "テンプレートの解析エラー: %w",
+	"output.template_execute":    "テンプレートの実行エラー: %w",
+	"output.template_required":   "template形式の出力には--formatまたは--format-fileを指定してください",
+	"output.invalid_format_name": "無効な出力形式名: %q",
+	"output.nil_factory":         "出力形式 %q のFactoryがnilです",
+	"output.duplicate_format":    "出力形式 %q は既に登録されています",
+	"output.unsupported_format":  "未対応の出力形式: %q (利用可能: %s)",
+	"output.create_file":         "出力ファイル作成エラー: %w",
+	"output.write_file":          "出力ファイル書き込みエラー: %w",
+	"output.unsupported_column":  "未対応の列: %s (%s のいずれかを指定してください)",
+
+	// テキストレポートの見出しとメッセージ
+	"report.request_summary":             "=== リクエスト概要 ===",
+	"report.results":                     "=== 結果 ===",
+	"report.summary":                     "=== サマリー ===",
+	"report.status_breakdown":            "=== ステータスコード別の内訳 ===",
+	"report.echo_verification":           "=== %s エコー検証 ===",
+	"report.all_echoed":                  "すべてのRequest IDがエコーされました",
+	"report.consistency":                 "=== レスポンスの差分 ===",
+	"report.all_consistent":              "すべてのレスポンスが一致しています",
+	"report.idempotency":                 "=== 冪等性検証 ===",
+	"report.idempotency_reference":       "基準: [%d]",
+	"report.idempotency_replays":         "リプレイ: %s",
+	"report.idempotency_conflicts":       "競合: %s",
+	"report.idempotency_result":          "判定: %s",
+	"report.violation_request_failed":    "リクエストが失敗しました: %v",
+	"report.violation_status_differs":    "ステータス %d が基準 #%d (%d) と異なります",
+	"report.violation_body_differs":      "ボディが基準 #%d と異なります",
+	"report.violation_unexpected_status": "想定外のステータス %d（リプレイでも競合ステータスでもありません）",
+	"report.violation_no_success":        "2xxで成功したリクエストがありません",
+	"report.clusters":                    "=== レスポンスのクラスター ===",
+	"report.cluster_representative":      "-- クラスター %s: 代表 [%d] --",
+	"report.latency":                     "=== 応答時間 ===",
+	"report.timeline":                    "=== タイムライン ===",
+	"report.overlap_none":                "重なり: なし（全リクエストが同時に実行中だった期間はありません）",
+	"report.overlap_all":                 "重なり: 全%d件のリクエストが%s間同時に実行中 (+%dms 〜 +%dms)",
+	"report.body_omitted":                "[ボディ省略]",
+	"report.body_not_json":               "[ボディがJSONではありません]",
+	"report.trailers":                    "-- トレーラー --",
+
+	// 進行状況
+	"progress.start":     "🚀 %d件の並行リクエストを開始 (%s)",
+	"progress.finished":  "🎉 全リクエストが%sで完了 (%s)",
+	"dashboard.running":  "%d件のリクエストを実行中: %d/%d 完了、経過 %s",
+	"dashboard.finished": "全%d件のリクエストが%sで完了",
+	"dashboard.more":     "…ほか%d件のリクエスト",
+	"dashboard.prompt":   "1-%dキーでリクエストの詳細を表示、qで終了",
+	"dashboard.request":  "=== リクエスト [%d] ===",
+}
@@ -0,0 +1,284 @@
+// Package jsonpath implements the subset of JSONPath used by conreq to select,
+// ignore and redact fields in JSON documents decoded with encoding/json.
+//
+// Supported syntax:
+//
+//	$                root
+//	.name ['name']   member
+//	[0] [-1]         array index (negative counts from the end)
+//	.* [*]           all members or elements
+//	..name           member at any depth
+package jsonpath
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+type segmentKind int
+
+const (
+	segmentField segmentKind = iota
+	segmentIndex
+	segmentWildcard
+	segmentRecursive
+)
+
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// Parse compiles a JSONPath expression. The leading "$" may be omitted.
+func Parse(expr string) (*Path, error) {
+	s := strings.TrimSpace(expr)
+	s = strings.TrimPrefix(s, "$")
+	p := &Path{expr: expr}
+
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := readName(s[2:])
+			if name == "" {
+				return nil, i18n.Errorf("jsonpath.missing_member", expr)
+			}
+			p.segments = append(p.segments, segment{kind: segmentRecursive, name: name})
+			s = rest
+		case s[0] == '.':
+			name, rest := readName(s[1:])
+			switch name {
+			case "":
+				return nil, i18n.Errorf("jsonpath.invalid", expr)
+			case "*":
+				p.segments = append(p.segments, segment{kind: segmentWildcard})
+			default:
+				p.segments = append(p.segments, segment{kind: segmentField, name: name})
+			}
+			s = rest
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, i18n.Errorf("jsonpath.missing_bracket", expr)
+			}
+			seg, err := parseBracket(s[1:end])
+			if err != nil {
+				return nil, i18n.Errorf("jsonpath.invalid_cause", expr, err)
+			}
+			p.segments = append(p.segments, seg)
+			s = s[end+1:]
+		default:
+			// "$" を省略した "order.status" 形式
+			if len(p.segments) > 0 {
+				return nil, i18n.Errorf("jsonpath.invalid", expr)
+			}
+			s = "." + s
+		}
+	}
+
+	return p, nil
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(expr string) *Path {
+	p, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ParseAll compiles several expressions.
+func ParseAll(exprs []string) ([]*Path, error) {
+	paths := make([]*Path, 0, len(exprs))
+	for _, expr := range exprs {
+		p, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func readName(s string) (name, rest string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+func parseBracket(inner string) (segment, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "*":
+		return segment{kind: segmentWildcard}, nil
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return segment{kind: segmentField, name: inner[1 : len(inner)-1]}, nil
+	}
+	index, err := strconv.Atoi(inner)
+	if err != nil {
+		return segment{}, i18n.Errorf("jsonpath.invalid_index", inner)
+	}
+	return segment{kind: segmentIndex, index: index}, nil
+}
+
+// String returns the original expression.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Get returns every value matched by the path.
+func (p *Path) Get(doc any) []any {
+	var matches []any
+	get(doc, p.segments, &matches)
+	return matches
+}
+
+func get(node any, segs []segment, matches *[]any) {
+	if len(segs) == 0 {
+		*matches = append(*matches, node)
+		return
+	}
+	seg, rest := segs[0], segs[1:]
+
+	if seg.kind == segmentRecursive {
+		get(node, append([]segment{{kind: segmentField, name: seg.name}}, rest...), matches)
+		forEachChild(node, func(child any) { get(child, segs, matches) })
+		return
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		for _, key := range matchKeys(n, seg) {
+			get(n[key], rest, matches)
+		}
+	case []any:
+		for _, i := range matchIndices(n, seg) {
+			get(n[i], rest, matches)
+		}
+	}
+}
+
+// Update calls fn for every value matched by the path and stores the value it
+// returns. When fn returns false the member is removed from its object, or
+// set to null when it is an array element so that indices stay stable.
+// doc is modified in place; the (possibly replaced) root is returned.
+func (p *Path) Update(doc any, fn func(old any) (any, bool)) any {
+	if len(p.segments) == 0 {
+		v, keep := fn(doc)
+		if !keep {
+			return nil
+		}
+		return v
+	}
+	update(doc, p.segments, fn)
+	return doc
+}
+
+// Delete removes every value matched by the path.
+func (p *Path) Delete(doc any) any {
+	return p.Update(doc, func(any) (any, bool) { return nil, false })
+}
+
+// Replace replaces every value matched by the path with value.
+func (p *Path) Replace(doc any, value any) any {
+	return p.Update(doc, func(any) (any, bool) { return value, true })
+}
+
+func update(node any, segs []segment, fn func(any) (any, bool)) {
+	seg, rest := segs[0], segs[1:]
+
+	if seg.kind == segmentRecursive {
+		update(node, append([]segment{{kind: segmentField, name: seg.name}}, rest...), fn)
+		forEachChild(node, func(child any) { update(child, segs, fn) })
+		return
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		for _, key := range matchKeys(n, seg) {
+			if len(rest) > 0 {
+				update(n[key], rest, fn)
+				continue
+			}
+			if v, keep := fn(n[key]); keep {
+				n[key] = v
+			} else {
+				delete(n, key)
+			}
+		}
+	case []any:
+		for _, i := range matchIndices(n, seg) {
+			if len(rest) > 0 {
+				update(n[i], rest, fn)
+				continue
+			}
+			v, keep := fn(n[i])
+			if !keep {
+				v = nil
+			}
+			n[i] = v
+		}
+	}
+}
+
+func forEachChild(node any, fn func(any)) {
+	switch n := node.(type) {
+	case map[string]any:
+		for _, key := range matchKeys(n, segment{kind: segmentWildcard}) {
+			fn(n[key])
+		}
+	case []any:
+		for _, child := range n {
+			fn(child)
+		}
+	}
+}
+
+func matchKeys(m map[string]any, seg segment) []string {
+	switch seg.kind {
+	case segmentField:
+		if _, ok := m[seg.name]; ok {
+			return []string{seg.name}
+		}
+	case segmentWildcard:
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return nil
+}
+
+func matchIndices(a []any, seg segment) []int {
+	switch seg.kind {
+	case segmentIndex:
+		i := seg.index
+		if i < 0 {
+			i += len(a)
+		}
+		if i >= 0 && i < len(a) {
+			return []int{i}
+		}
+	case segmentWildcard:
+		indices := make([]int, len(a))
+		for i := range a {
+			indices[i] = i
+		}
+		return indices
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const doc = `{
+  "order": {"id": 42, "status": "paid", "version": 3, "updated_at": "2025-01-01T00:00:00Z"},
+  "items": [{"sku": "a", "updated_at": "x"}, {"sku": "b", "updated_at": "y"}],
+  "key with space": true
+}`
+
+func decode(t *testing.T) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []any
+	}{
+		{"$.order.status", []any{"paid"}},
+		{"order.version", []any{float64(3)}},
+		{"$['order']['id']", []any{float64(42)}},
+		{"$.items[1].sku", []any{"b"}},
+		{"$.items[-1].sku", []any{"b"}},
+		{"$.items[*].sku", []any{"a", "b"}},
+		{"$['key with space']", []any{true}},
+		{"$.missing", nil},
+		{"$.items[5]", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := p.Get(decode(t)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	v := decode(t)
+	p := MustParse("$..updated_at")
+	if got := p.Get(v); len(got) != 3 {
+		t.Errorf("Get() = %v, want 3 matches", got)
+	}
+
+	p.Delete(v)
+	if got := p.Get(v); len(got) != 0 {
+		t.Errorf("after Delete() Get() = %v, want none", got)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	v := decode(t)
+	MustParse("$.order.status").Replace(v, "[REDACTED]")
+	MustParse("$.items[0]").Delete(v)
+	MustParse("$.order.version").Delete(v)
+
+	order := v.(map[string]any)["order"].(map[string]any)
+	if order["status"] != "[REDACTED]" {
+		t.Errorf("status = %v, want [REDACTED]", order["status"])
+	}
+	if _, ok := order["version"]; ok {
+		t.Error("version was not deleted")
+	}
+	items := v.(map[string]any)["items"].([]any)
+	if len(items) != 2 || items[0] != nil {
+		t.Errorf("items = %v, want first element nulled", items)
+	}
+
+	if got := MustParse("$").Replace(v, "root"); got != "root" {
+		t.Errorf("Replace() of root = %v", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"$.", "$[", "$[abc]", "$..", "$.a b[0]x"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", expr)
+		}
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	ignore := []*Path{MustParse("$.ts")}
+
+	a, isJSON := NormalizeBody(`{"b":1,"a":2,"ts":"x"}`, ignore)
+	b, _ := NormalizeBody(`{"a":2, "b":1, "ts":"y"}`, ignore)
+	if !isJSON || a != b {
+		t.Errorf("NormalizeBody() = %q / %q, want equal JSON", a, b)
+	}
+
+	if got, isJSON := NormalizeBody("plain text", ignore); isJSON || got != "plain text" {
+		t.Errorf("NormalizeBody(text) = %q, %v", got, isJSON)
+	}
+	if got, _ := NormalizeBody(`{"n": 12345678901234567890}`, nil); got != "{\n  \"n\": 12345678901234567890\n}" {
+		t.Errorf("NormalizeBody() lost number precision: %q", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	paths := []*Path{
+		MustParse("$.order.status"),
+		MustParse(".order.version"),
+		MustParse("$.items[*].sku"),
+		MustParse("$.missing"),
+	}
+
+	got, isJSON := Select(doc, paths)
+	if !isJSON {
+		t.Fatal("Select() reported a JSON body as not JSON")
+	}
+	want := map[string]any{
+		"$.order.status": "paid",
+		".order.version": json.Number("3"),
+		"$.items[*].sku": []any{"a", "b"},
+		"$.missing":      nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Select() = %#v, want %#v", got, want)
+	}
+
+	if got, isJSON := Select("plain text", paths); isJSON || got != nil {
+		t.Errorf("Select(text) = %#v, %v", got, isJSON)
+	}
+}
@@ -0,0 +1,29 @@
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// NormalizeBody returns body in a canonical form for comparison.
+// JSON bodies are decoded, the ignored paths are removed and the document is
+// re-encoded with sorted keys and indentation. Other bodies are returned as is.
+// The second result reports whether the body was JSON.
+func NormalizeBody(body string, ignore []*Path) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return body, false
+	}
+	for _, p := range ignore {
+		doc = p.Delete(doc)
+	}
+
+	normalized, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return body, false
+	}
+	return string(normalized), true
+}
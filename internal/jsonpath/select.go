@@ -0,0 +1,36 @@
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Select evaluates paths against a JSON body and returns the matched values
+// keyed by expression. A path with one match maps to that value, a path with
+// several matches (wildcards, recursive descent) maps to the list of values
+// and a path without a match maps to nil. Numbers are kept as json.Number so
+// that they are reproduced exactly. The second result reports whether the
+// body was JSON.
+func Select(body string, paths []*Path) (map[string]any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return nil, false
+	}
+
+	selected := make(map[string]any, len(paths))
+	for _, p := range paths {
+		matches := p.Get(doc)
+		switch len(matches) {
+		case 0:
+			selected[p.String()] = nil
+		case 1:
+			selected[p.String()] = matches[0]
+		default:
+			selected[p.String()] = matches
+		}
+	}
+	return selected, true
+}
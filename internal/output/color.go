@@ -0,0 +1,215 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// ANSI escape sequences.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiGray    = "\x1b[90m"
+)
+
+// palette applies ANSI colours when enabled and returns text unchanged otherwise.
+type palette struct {
+	enabled bool
+}
+
+func (p palette) paint(code, text string) string {
+	if !p.enabled || text == "" {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// status colours text by the class of an HTTP status code.
+func (p palette) status(code int, text string) string {
+	switch {
+	case code >= 500:
+		return p.paint(ansiRed, text)
+	case code >= 400:
+		return p.paint(ansiYellow, text)
+	case code >= 300:
+		return p.paint(ansiCyan, text)
+	case code >= 200:
+		return p.paint(ansiGreen, text)
+	}
+	return text
+}
+
+func (p palette) error(text string) string {
+	return p.paint(ansiBold+ansiRed, text)
+}
+
+// formatBody pretty-prints JSON and XML bodies according to contentType and
+// highlights their syntax when colour is enabled. Other bodies, and bodies
+// that fail to parse, are returned unchanged.
+func (p palette) formatBody(body, contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return body
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(body), "", "  "); err != nil {
+			return body
+		}
+		if p.enabled {
+			return p.highlightJSON(buf.String())
+		}
+		return buf.String()
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		pretty, err := p.indentXML(body)
+		if err != nil {
+			return body
+		}
+		return pretty
+	}
+	return body
+}
+
+// highlightJSON colours keys, strings, numbers and literals of indented JSON.
+func (p palette) highlightJSON(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(s))
+			token := s[i:end]
+			// 直後が ':' ならキー
+			rest := strings.TrimLeft(s[end:], " ")
+			if strings.HasPrefix(rest, ":") {
+				sb.WriteString(p.paint(ansiBlue, token))
+			} else {
+				sb.WriteString(p.paint(ansiGreen, token))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i
+			for end < len(s) && strings.IndexByte("+-.eE0123456789", s[end]) >= 0 {
+				end++
+			}
+			sb.WriteString(p.paint(ansiYellow, s[i:end]))
+			i = end
+		case strings.HasPrefix(s[i:], "true"), strings.HasPrefix(s[i:], "null"):
+			sb.WriteString(p.paint(ansiMagenta, s[i:i+4]))
+			i += 4
+		case strings.HasPrefix(s[i:], "false"):
+			sb.WriteString(p.paint(ansiMagenta, s[i:i+5]))
+			i += 5
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// indentXML re-indents an XML document, keeping text content on the line of its element.
+func (p palette) indentXML(body string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	decoder.Strict = false
+
+	var sb strings.Builder
+	depth := 0
+	// 直前に書いたものが開始タグ・テキストなら終了タグを同じ行に続ける
+	inline := false
+	newline := func() {
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(strings.Repeat("  ", depth))
+	}
+
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			newline()
+			sb.WriteString(p.paint(ansiBlue, "<"+xmlName(t.Name)))
+			for _, attr := range t.Attr {
+				var value bytes.Buffer
+				_ = xml.EscapeText(&value, []byte(attr.Value))
+				fmt.Fprintf(&sb, " %s=%s", p.paint(ansiCyan, xmlName(attr.Name)), p.paint(ansiGreen, `"`+value.String()+`"`))
+			}
+			sb.WriteString(p.paint(ansiBlue, ">"))
+			depth++
+			inline = true
+		case xml.EndElement:
+			depth--
+			if !inline {
+				newline()
+			}
+			sb.WriteString(p.paint(ansiBlue, "</"+xmlName(t.Name)+">"))
+			inline = false
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+			if !inline {
+				newline()
+			}
+			var escaped bytes.Buffer
+			_ = xml.EscapeText(&escaped, []byte(text))
+			sb.WriteString(escaped.String())
+		case xml.Comment:
+			newline()
+			sb.WriteString(p.paint(ansiGray, "<!--"+string(t)+"-->"))
+			inline = false
+		case xml.ProcInst:
+			newline()
+			fmt.Fprintf(&sb, "<?%s %s?>", t.Target, t.Inst)
+			inline = false
+		case xml.Directive:
+			newline()
+			fmt.Fprintf(&sb, "<!%s>", t)
+			inline = false
+		}
+	}
+
+	if depth != 0 {
+		return "", i18n.Errorf("output.xml_unclosed")
+	}
+	return sb.String(), nil
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaletteFormatBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{
+			name:        "json",
+			body:        `{"order":{"id":1,"tags":["a"]}}`,
+			contentType: "application/json; charset=utf-8",
+			want:        "{\n  \"order\": {\n    \"id\": 1,\n    \"tags\": [\n      \"a\"\n    ]\n  }\n}",
+		},
+		{
+			name:        "json suffix",
+			body:        `{"title":"Not Found"}`,
+			contentType: "application/problem+json",
+			want:        "{\n  \"title\": \"Not Found\"\n}",
+		},
+		{
+			name:        "xml",
+			body:        `<?xml version="1.0"?><order id="1"><status>paid</status><items><item/></items></order>`,
+			contentType: "application/xml",
+			want:        "<?xml version=\"1.0\"?>\n<order id=\"1\">\n  <status>paid</status>\n  <items>\n    <item></item>\n  </items>\n</order>",
+		},
+		{
+			name:        "xml with namespace prefix",
+			body:        `<soap:Envelope xmlns:soap="urn:x"><soap:Body>ok</soap:Body></soap:Envelope>`,
+			contentType: "text/xml",
+			want:        "<soap:Envelope xmlns:soap=\"urn:x\">\n  <soap:Body>ok</soap:Body>\n</soap:Envelope>",
+		},
+		{
+			name:        "invalid json is unchanged",
+			body:        `{"broken":`,
+			contentType: "application/json",
+			want:        `{"broken":`,
+		},
+		{
+			name:        "unclosed xml is unchanged",
+			body:        `<a><b></b>`,
+			contentType: "application/xml",
+			want:        `<a><b></b>`,
+		},
+		{
+			name:        "other content types are unchanged",
+			body:        `{"a":1}`,
+			contentType: "text/plain",
+			want:        `{"a":1}`,
+		},
+		{
+			name:        "missing content type",
+			body:        `{"a":1}`,
+			contentType: "",
+			want:        `{"a":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (palette{}).formatBody(tt.body, tt.contentType); got != tt.want {
+				t.Errorf("formatBody() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaletteColors(t *testing.T) {
+	colored := palette{enabled: true}
+
+	got := colored.formatBody(`{"ok":true,"n":-2,"s":"x"}`, "application/json")
+	for _, want := range []string{
+		ansiBlue + `"ok"` + ansiReset,
+		ansiMagenta + "true" + ansiReset,
+		ansiYellow + "-2" + ansiReset,
+		ansiGreen + `"x"` + ansiReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatBody() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	statuses := map[int]string{200: ansiGreen, 302: ansiCyan, 409: ansiYellow, 503: ansiRed}
+	for code, color := range statuses {
+		if got := colored.status(code, "x"); got != color+"x"+ansiReset {
+			t.Errorf("status(%d) = %q", code, got)
+		}
+	}
+
+	if got := (palette{}).status(500, "500"); got != "500" {
+		t.Errorf("disabled status() = %q, want plain text", got)
+	}
+	if got := (palette{}).error("ERROR"); got != "ERROR" {
+		t.Errorf("disabled error() = %q, want plain text", got)
+	}
+}
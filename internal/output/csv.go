@@ -0,0 +1,146 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// CSV columns.
+const (
+	ColumnIndex     = "index"
+	ColumnRequestID = "request_id"
+	ColumnStart     = "start"
+	ColumnEnd       = "end"
+	ColumnDuration  = "duration_ms"
+	ColumnStatus    = "status"
+	ColumnError     = "error"
+	ColumnBodySize  = "body_size"
+	ColumnBodyHash  = "body_hash"
+)
+
+// CSVColumns lists every available column in their default order.
+var CSVColumns = []string{
+	ColumnIndex, ColumnRequestID, ColumnStart, ColumnEnd,
+	ColumnDuration, ColumnStatus, ColumnError, ColumnBodySize, ColumnBodyHash,
+}
+
+// ValidateCSVColumns checks that every column name is known.
+func ValidateCSVColumns(columns []string) error {
+	for _, column := range columns {
+		if !isCSVColumn(column) {
+			return i18n.Errorf("output.unsupported_column", column, strings.Join(CSVColumns, ", "))
+		}
+	}
+	return nil
+}
+
+func isCSVColumn(column string) bool {
+	for _, c := range CSVColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// CSVFormatter formats results as CSV or TSV with one row per response.
+// The header row is written only once, so several runs can be appended to the
+// same writer.
+type CSVFormatter struct {
+	writer        *csv.Writer
+	config        *config.Config
+	columns       []string
+	header        bool
+	headerWritten bool
+}
+
+// NewCSVFormatter creates a CSV formatter. Use '\t' as comma for TSV.
+// columns selects and orders the columns (CSVColumns when empty) and
+// header controls whether a header row is written.
+func NewCSVFormatter(w io.Writer, cfg *config.Config, comma rune, columns []string, header bool) *CSVFormatter {
+	if len(columns) == 0 {
+		columns = CSVColumns
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	return &CSVFormatter{
+		writer:  writer,
+		config:  cfg,
+		columns: columns,
+		header:  header,
+	}
+}
+
+// Format writes one row per response, ordered by request index.
+func (f *CSVFormatter) Format(result *runner.Result) error {
+	if err := ValidateCSVColumns(f.columns); err != nil {
+		return err
+	}
+	ignore, err := jsonpath.ParseAll(f.config.IgnoreJSONPaths)
+	if err != nil {
+		return err
+	}
+
+	if f.header && !f.headerWritten {
+		if err := f.writer.Write(f.columns); err != nil {
+			return err
+		}
+		f.headerWritten = true
+	}
+
+	for _, resp := range sortByIndex(result.Responses) {
+		record := make([]string, len(f.columns))
+		for i, column := range f.columns {
+			record[i] = f.value(column, result, resp, ignore)
+		}
+		if err := f.writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	f.writer.Flush()
+	return f.writer.Error()
+}
+
+func (f *CSVFormatter) value(column string, result *runner.Result, resp *client.Response, ignore []*jsonpath.Path) string {
+	switch column {
+	case ColumnIndex:
+		return fmt.Sprintf("%d", resp.RequestIndex+1)
+	case ColumnRequestID:
+		return resp.RequestID
+	case ColumnStart:
+		return resp.Timestamp.Format(time.RFC3339Nano)
+	case ColumnEnd:
+		return resp.Timestamp.Add(resp.Duration).Format(time.RFC3339Nano)
+	case ColumnDuration:
+		return fmt.Sprintf("%.3f", milliseconds(resp.Duration))
+	case ColumnStatus:
+		if resp.Error != nil {
+			return "ERROR"
+		}
+		return fmt.Sprintf("%d", resp.StatusCode)
+	case ColumnError:
+		if resp.Error != nil {
+			return resp.Error.Error()
+		}
+	case ColumnBodySize:
+		if resp.Error == nil {
+			return fmt.Sprintf("%d", len(resp.Body))
+		}
+	case ColumnBodyHash:
+		// クラスタリングと同じフィンガープリントを使う
+		if resp.Error == nil {
+			return result.ResponseBodyHash(resp, ignore)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestCSVFormatter(t *testing.T) {
+	cfg := config.NewConfig()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &runner.Result{
+		Config: cfg,
+		Responses: []*client.Response{
+			{RequestIndex: 1, RequestID: "id-2", Error: context.DeadlineExceeded, Timestamp: start, Duration: time.Second},
+			{RequestIndex: 0, RequestID: "id-1", StatusCode: 201, Body: `{"id":1}`, Timestamp: start, Duration: 1500 * time.Microsecond},
+		},
+	}
+	hash := runner.BodyHash(`{"id":1}`, nil)
+
+	tests := []struct {
+		name    string
+		comma   rune
+		columns []string
+		header  bool
+		runs    int
+		want    [][]string
+	}{
+		{
+			name:   "default columns",
+			comma:  ',',
+			header: true,
+			runs:   1,
+			want: [][]string{
+				CSVColumns,
+				{"1", "id-1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00.0015Z", "1.500", "201", "", "8", hash},
+				{"2", "id-2", "2025-01-01T00:00:00Z", "2025-01-01T00:00:01Z", "1000.000", "ERROR", "context deadline exceeded", "", ""},
+			},
+		},
+		{
+			name:    "selected columns without header",
+			comma:   '\t',
+			columns: []string{ColumnStatus, ColumnIndex},
+			runs:    1,
+			want: [][]string{
+				{"201", "1"},
+				{"ERROR", "2"},
+			},
+		},
+		{
+			name:    "header is written once across runs",
+			comma:   ',',
+			columns: []string{ColumnIndex},
+			header:  true,
+			runs:    2,
+			want: [][]string{
+				{"index"},
+				{"1"},
+				{"2"},
+				{"1"},
+				{"2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := NewCSVFormatter(&buf, cfg, tt.comma, tt.columns, tt.header)
+			for range tt.runs {
+				if err := f.Format(result); err != nil {
+					t.Fatalf("Format() error = %v", err)
+				}
+			}
+
+			reader := csv.NewReader(strings.NewReader(buf.String()))
+			reader.Comma = tt.comma
+			got, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("invalid output: %v\n%s", err, buf.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCSVColumns(t *testing.T) {
+	if err := ValidateCSVColumns([]string{ColumnIndex, ColumnBodyHash}); err != nil {
+		t.Errorf("ValidateCSVColumns() error = %v", err)
+	}
+	if err := ValidateCSVColumns([]string{"latency"}); err == nil {
+		t.Error("ValidateCSVColumns() error = nil, want error for unknown column")
+	}
+}
@@ -0,0 +1,299 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/terminal"
+)
+
+// dashboardInterval is how often the dashboard redraws running requests.
+const dashboardInterval = 100 * time.Millisecond
+
+// ANSI sequences used to redraw the dashboard in place.
+const (
+	cursorUp   = "\x1b[%dA"
+	clearLine  = "\r\x1b[2K"
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+)
+
+// spinnerFrames animate the rows of running requests.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Dashboard is an interactive progress display for terminals. Unlike
+// ProgressFormatter, which appends a line per event, it keeps one row per
+// request and redraws the rows in place with the elapsed time, the status
+// code and the number of bytes received.
+type Dashboard struct {
+	writer    io.Writer
+	colors    palette
+	width     int
+	height    int // 0は不明
+	startTime time.Time
+	rows      []*runner.Progress
+	frame     int
+	drawn     int // 直前に描画した行数
+	finished  bool
+	now       func() time.Time
+}
+
+// NewDashboard creates a dashboard for totalCount requests.
+func NewDashboard(w io.Writer, totalCount int) *Dashboard {
+	rows := make([]*runner.Progress, totalCount)
+	for i := range rows {
+		rows[i] = &runner.Progress{Index: i, Status: "pending"}
+	}
+	return &Dashboard{
+		writer:    w,
+		width:     terminal.Width(w),
+		height:    terminal.Height(w),
+		startTime: time.Now(),
+		rows:      rows,
+		now:       time.Now,
+	}
+}
+
+// SetColor enables or disables ANSI colours for status columns.
+func (d *Dashboard) SetColor(enabled bool) {
+	d.colors = palette{enabled: enabled}
+}
+
+// Run draws the dashboard and redraws it on every progress update and every
+// dashboardInterval until the progress channel is closed.
+func (d *Dashboard) Run(progress <-chan *runner.Progress) {
+	ticker := time.NewTicker(dashboardInterval)
+	defer ticker.Stop()
+
+	_, _ = io.WriteString(d.writer, hideCursor)
+	defer func() { _, _ = io.WriteString(d.writer, showCursor) }()
+
+	d.Render()
+	for {
+		select {
+		case p, ok := <-progress:
+			if !ok {
+				d.finished = true
+				d.Render()
+				return
+			}
+			d.Update(p)
+			d.Render()
+		case <-ticker.C:
+			d.frame++
+			d.Render()
+		}
+	}
+}
+
+// Update records a progress event in the row of its request.
+func (d *Dashboard) Update(p *runner.Progress) {
+	if p.Index >= 0 && p.Index < len(d.rows) {
+		d.rows[p.Index] = p
+	}
+}
+
+// Render redraws the rows, replacing the previous drawing. When the rows do
+// not fit in the terminal, only those that fit are drawn, followed by the
+// number of rows left out.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (d *Dashboard) Render() {
+	var sb strings.Builder
+	if d.drawn > 0 {
+		fmt.Fprintf(&sb, cursorUp, d.drawn)
+	}
+
+	rows, hidden := d.visibleRows()
+	lines := make([]string, 0, len(rows)+2)
+	lines = append(lines, truncateRunes(d.header(), d.width-1))
+	for _, p := range rows {
+		lines = append(lines, d.row(p))
+	}
+	if hidden > 0 {
+		lines = append(lines, truncateRunes(i18n.T("dashboard.more", hidden), d.width-1))
+	}
+	for _, line := range lines {
+		sb.WriteString(clearLine)
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	d.drawn = len(lines)
+
+	io.WriteString(d.writer, sb.String())
+}
+
+// visibleRows returns the rows to draw and the number of rows left out. The
+// cursor can only move back over lines that are still on the screen, so when
+// the rows do not fit, a window starting at the first unfinished request is
+// returned.
+func (d *Dashboard) visibleRows() ([]*runner.Progress, int) {
+	// ヘッダーと末尾の改行の分を除いた行数に収まるか
+	if d.height <= 0 || len(d.rows)+2 <= d.height {
+		return d.rows, 0
+	}
+	// ヘッダー・省略行・末尾の改行の分を除く
+	n := max(d.height-3, 0)
+	start := len(d.rows)
+	for i, p := range d.rows {
+		if p.Status != "completed" && p.Status != "failed" {
+			start = i
+			break
+		}
+	}
+	start = min(start, len(d.rows)-n)
+	return d.rows[start : start+n], len(d.rows) - n
+}
+
+func (d *Dashboard) header() string {
+	done := 0
+	for _, p := range d.rows {
+		if p.Status == "completed" || p.Status == "failed" {
+			done++
+		}
+	}
+	elapsed := formatDuration(d.now().Sub(d.startTime))
+	if d.finished {
+		return i18n.T("dashboard.finished", len(d.rows), elapsed)
+	}
+	return i18n.T("dashboard.running", len(d.rows), done, len(d.rows), elapsed)
+}
+
+// row formats one request. Padding is applied before colouring so that
+// escape sequences do not break the alignment, and the plain text is
+// truncated to the terminal width so that the cursor movements stay correct.
+func (d *Dashboard) row(p *runner.Progress) string {
+	icon, status, code, elapsed, received := " ", "PENDING", "-", "-", "-"
+	switch p.Status {
+	case "running":
+		icon = spinnerFrames[d.frame%len(spinnerFrames)]
+		status = "RUNNING"
+		elapsed = formatDuration(d.now().Sub(p.StartTime))
+	case "completed":
+		icon = "✓"
+		status = "DONE"
+		code = fmt.Sprintf("%d", p.StatusCode)
+		elapsed = formatDuration(p.EndTime.Sub(p.StartTime))
+	case "failed":
+		icon = "✗"
+		status = "FAILED"
+		elapsed = formatDuration(p.EndTime.Sub(p.StartTime))
+	}
+	if p.BytesReceived != nil {
+		received = formatBytes(p.BytesReceived())
+	}
+
+	detail := p.RequestID
+	if p.Error != nil {
+		detail = p.Error.Error()
+	}
+
+	prefix := fmt.Sprintf("%s [%d] ", icon, p.Index+1)
+	columns := fmt.Sprintf("%-8s %4s %9s %9s  ", status, code, elapsed, received)
+	line := truncateRunes(prefix+columns+detail, d.width-1)
+
+	// 色付けはステータス列と詳細列にのみ行う
+	rest, ok := strings.CutPrefix(line, prefix+columns)
+	if !ok {
+		return line
+	}
+	switch p.Status {
+	case "completed":
+		return d.colors.status(p.StatusCode, prefix+columns) + rest
+	case "failed":
+		return d.colors.error(prefix+columns) + d.colors.error(rest)
+	}
+	return line
+}
+
+// Browse lets the user open the details of a request by pressing its number
+// once the run has finished. It reads single key presses from keys until q,
+// Enter, Esc, Ctrl-C, Ctrl-D or the end of input.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (d *Dashboard) Browse(result *runner.Result, keys io.Reader) error {
+	responses := sortByIndex(result.Responses)
+	if len(responses) == 0 {
+		return nil
+	}
+	prompt := "\n" + i18n.T("dashboard.prompt", min(len(responses), 9)) + "\n"
+	io.WriteString(d.writer, prompt)
+
+	key := make([]byte, 1)
+	for {
+		n, err := keys.Read(key)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+
+		switch k := key[0]; {
+		case k == 'q' || k == 'Q' || k == '\r' || k == '\n' || k == 0x1b || k == 0x03 || k == 0x04:
+			return nil
+		case k >= '1' && k <= '9' && int(k-'1') < len(responses):
+			d.showResponse(result, responses[k-'1'])
+			io.WriteString(d.writer, prompt)
+		}
+	}
+}
+
+// showResponse prints the headers and the body of a response.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (d *Dashboard) showResponse(result *runner.Result, resp *client.Response) {
+	fmt.Fprintln(d.writer, "\n"+i18n.T("dashboard.request", resp.RequestIndex+1))
+	fmt.Fprintf(d.writer, "%s: %s\n", result.Config.RequestIDHeader, resp.RequestID)
+	fmt.Fprintf(d.writer, "Time: %s | Received: %s\n", formatDuration(resp.Duration), formatBytes(int64(len(resp.Body))))
+	if resp.Error != nil {
+		fmt.Fprintf(d.writer, "Error: %s\n", d.colors.error(resp.Error.Error()))
+		return
+	}
+
+	fmt.Fprintln(d.writer, d.colors.status(resp.StatusCode, fmt.Sprintf("%s %d %s", resp.Proto, resp.StatusCode, resp.StatusText)))
+	writeHeaders(d.writer, resp.Headers)
+	fmt.Fprintln(d.writer)
+	if result.Config.NoBody {
+		fmt.Fprintln(d.writer, i18n.T("report.body_omitted"))
+	} else {
+		fmt.Fprintln(d.writer, d.colors.formatBody(resp.Body, resp.Headers.Get("Content-Type")))
+	}
+}
+
+// formatBytes formats a byte count with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, s := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, s
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
+
+// truncateRunes shortens s to at most n runes.
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,250 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestDashboardRender(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	d := NewDashboard(&buf, 3)
+	d.width = 80
+	d.startTime = base
+	d.now = func() time.Time { return base.Add(1500 * time.Millisecond) }
+
+	d.Render()
+	first := buf.String()
+	if !strings.HasPrefix(first, clearLine) {
+		t.Errorf("first render moves the cursor: %q", first)
+	}
+	if !strings.Contains(first, "Running 3 requests: 0/3 done, 1.50s elapsed") {
+		t.Errorf("header missing:\n%s", first)
+	}
+
+	d.Update(&runner.Progress{Index: 0, RequestID: "id-1", Status: "completed", StatusCode: 201,
+		StartTime: base, EndTime: base.Add(120 * time.Millisecond), BytesReceived: func() int64 { return 2048 }})
+	d.Update(&runner.Progress{Index: 1, RequestID: "id-2", Status: "running",
+		StartTime: base.Add(time.Second), BytesReceived: func() int64 { return 512 }})
+	d.Update(&runner.Progress{Index: 2, RequestID: "id-3", Status: "failed", Error: errors.New("connection refused"),
+		StartTime: base, EndTime: base.Add(3 * time.Millisecond)})
+
+	buf.Reset()
+	d.Render()
+	got := buf.String()
+
+	if !strings.HasPrefix(got, "\x1b[4A") {
+		t.Errorf("redraw does not move the cursor up over the previous 4 lines: %q", got)
+	}
+	for _, want := range []string{
+		clearLine + "Running 3 requests: 2/3 done, 1.50s elapsed\n",
+		clearLine + "✓ [1] DONE      201     120ms     2.0KB  id-1\n",
+		clearLine + spinnerFrames[0] + " [2] RUNNING     -     500ms      512B  id-2\n",
+		clearLine + "✗ [3] FAILED      -       3ms         -  connection refused\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render does not contain %q:\n%s", want, got)
+		}
+	}
+
+	d.frame = 1
+	buf.Reset()
+	d.Render()
+	if !strings.Contains(buf.String(), spinnerFrames[1]+" [2] RUNNING") {
+		t.Errorf("spinner did not advance:\n%s", buf.String())
+	}
+}
+
+func TestDashboardRenderTruncates(t *testing.T) {
+	var buf bytes.Buffer
+	d := NewDashboard(&buf, 1)
+	d.width = 30
+	d.Update(&runner.Progress{Index: 0, RequestID: strings.Repeat("x", 100), Status: "pending"})
+	d.Render()
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		line = strings.TrimPrefix(line, clearLine)
+		if n := len([]rune(line)); n > 29 {
+			t.Errorf("line is %d runes wide, want at most 29: %q", n, line)
+		}
+	}
+}
+
+func TestDashboardRenderClampsToHeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		height    int
+		completed int
+		want      []string
+	}{
+		{
+			name:   "rows fit",
+			height: 7,
+			want:   []string{"Running 5 requests", "[1]", "[2]", "[3]", "[4]", "[5]"},
+		},
+		{
+			name:   "unknown height",
+			height: 0,
+			want:   []string{"Running 5 requests", "[1]", "[2]", "[3]", "[4]", "[5]"},
+		},
+		{
+			name:      "window starts at the first unfinished request",
+			height:    5,
+			completed: 2,
+			want:      []string{"Running 5 requests", "[3]", "[4]", "... and 3 more requests"},
+		},
+		{
+			name:      "window stays within the rows",
+			height:    5,
+			completed: 5,
+			want:      []string{"Running 5 requests", "[4]", "[5]", "... and 3 more requests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			d := NewDashboard(&buf, 5)
+			d.width = 80
+			d.height = tt.height
+			for i := range tt.completed {
+				d.Update(&runner.Progress{Index: i, Status: "completed", StatusCode: 200})
+			}
+
+			d.Render()
+			buf.Reset()
+			d.Render()
+
+			got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if len(got) != len(tt.want) {
+				t.Fatalf("rendered %d lines, want %d:\n%s", len(got), len(tt.want), buf.String())
+			}
+			// 再描画でカーソルを戻す行数が画面の高さに収まること
+			if want := fmt.Sprintf(cursorUp, len(tt.want)); !strings.HasPrefix(got[0], want) {
+				t.Errorf("redraw does not start with %q: %q", want, got[0])
+			}
+			if tt.height > 0 && len(got) >= tt.height {
+				t.Errorf("rendered %d lines on a %d-line terminal", len(got), tt.height)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("line %d = %q, want it to contain %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardRun(t *testing.T) {
+	var buf bytes.Buffer
+	d := NewDashboard(&buf, 1)
+	d.width = 80
+
+	progress := make(chan *runner.Progress, 2)
+	progress <- &runner.Progress{Index: 0, RequestID: "id-1", Status: "running", StartTime: time.Now()}
+	progress <- &runner.Progress{Index: 0, RequestID: "id-1", Status: "completed", StatusCode: 200, StartTime: time.Now(), EndTime: time.Now()}
+	close(progress)
+	d.Run(progress)
+
+	got := buf.String()
+	if !strings.HasPrefix(got, hideCursor) || !strings.HasSuffix(got, showCursor) {
+		t.Errorf("cursor is not hidden and restored: %q", got)
+	}
+	if !strings.Contains(got, "All 1 requests completed in") || !strings.Contains(got, "✓ [1] DONE") {
+		t.Errorf("final render missing:\n%s", got)
+	}
+}
+
+func TestDashboardBrowse(t *testing.T) {
+	cfg := config.NewConfig()
+	result := &runner.Result{
+		Config: cfg,
+		Responses: []*client.Response{
+			{RequestIndex: 1, RequestID: "id-2", Error: errors.New("connection refused")},
+			{RequestIndex: 0, RequestID: "id-1", Proto: "HTTP/1.1", StatusCode: 200, StatusText: "OK",
+				Headers: http.Header{"Content-Type": []string{"application/json"}}, Body: `{"ok":true}`},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		keys    string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "show requests until q",
+			keys: "2x1q2",
+			want: []string{
+				"=== Request [2] ===\nX-Request-ID: id-2\nTime: 0µs | Received: 0B\nError: connection refused\n",
+				"=== Request [1] ===\nX-Request-ID: id-1\nTime: 0µs | Received: 11B\nHTTP/1.1 200 OK\nContent-Type: application/json\n\n{\n  \"ok\": true\n}\n",
+			},
+		},
+		{
+			name:    "out of range and end of input",
+			keys:    "9",
+			notWant: []string{"=== Request"},
+		},
+		{
+			name:    "enter quits",
+			keys:    "\r1",
+			notWant: []string{"=== Request"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			d := NewDashboard(&buf, 2)
+			if err := d.Browse(result, strings.NewReader(tt.keys)); err != nil {
+				t.Fatalf("Browse() error = %v", err)
+			}
+			got := buf.String()
+			if !strings.HasPrefix(got, "\nPress 1-2 to show a request, q to quit\n") {
+				t.Errorf("prompt missing: %q", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output does not contain %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, got)
+				}
+			}
+			if strings.Count(got, "=== Request [2]") > 1 {
+				t.Errorf("keys after q were processed:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KB"},
+		{1536, "1.5KB"},
+		{5 * 1024 * 1024, "5.0MB"},
+		{3 * 1024 * 1024 * 1024, "3.0GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
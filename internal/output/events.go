@@ -0,0 +1,125 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// Event types written by EventFormatter.
+const (
+	EventStart    = "start"
+	EventProgress = "progress"
+	EventSummary  = "summary"
+	EventResult   = "result"
+)
+
+// Event is a single line of the NDJSON event stream.
+// Only the fields relevant to the event type are set.
+type Event struct {
+	Event string `json:"event"`
+	Time  string `json:"time"`
+
+	// start
+	URL        string `json:"url,omitempty"`
+	Method     string `json:"method,omitempty"`
+	Concurrent int    `json:"concurrent,omitempty"`
+
+	// progress
+	Index      int    `json:"index,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	Status     string `json:"status,omitempty"` // pending, running, completed, failed
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  string `json:"started_at,omitempty"`
+	EndedAt    string `json:"ended_at,omitempty"`
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+
+	// summary / result
+	Summary *SpecJSONSummary `json:"summary,omitempty"`
+	Result  *SpecJSONOutput  `json:"result,omitempty"`
+}
+
+// EventFormatter writes runner progress as newline-delimited JSON (NDJSON),
+// one event per line, for tools that consume progress while requests run.
+// A stream consists of a start event, progress events for every state change
+// and a final summary event.
+type EventFormatter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	config  *config.Config
+}
+
+// NewEventFormatter creates a new NDJSON event formatter.
+func NewEventFormatter(w io.Writer, cfg *config.Config) *EventFormatter {
+	return &EventFormatter{encoder: json.NewEncoder(w), config: cfg}
+}
+
+// Start writes the start event.
+func (f *EventFormatter) Start() error {
+	return f.write(&Event{
+		Event:      EventStart,
+		URL:        f.config.URL,
+		Method:     f.config.Method,
+		Concurrent: f.config.Count,
+	})
+}
+
+// FormatProgress writes a progress event.
+func (f *EventFormatter) FormatProgress(p *runner.Progress) error {
+	event := &Event{
+		Event:      EventProgress,
+		Index:      p.Index + 1,
+		RequestID:  p.RequestID,
+		Status:     p.Status,
+		StatusCode: p.StatusCode,
+		StartedAt:  formatEventTime(p.StartTime),
+		EndedAt:    formatEventTime(p.EndTime),
+	}
+	if p.Error != nil {
+		event.Error = p.Error.Error()
+	}
+	if !p.EndTime.IsZero() {
+		durationMs := p.EndTime.Sub(p.StartTime).Milliseconds()
+		event.DurationMs = &durationMs
+	}
+	return f.write(event)
+}
+
+// Finish writes the summary event that terminates the stream.
+func (f *EventFormatter) Finish(result *runner.Result) error {
+	output, err := NewSpecJSONFormatter(nil, f.config).Build(result)
+	if err != nil {
+		return err
+	}
+	return f.write(&Event{Event: EventSummary, Summary: &output.Summary})
+}
+
+// Format writes the complete JSON result as a single result event, so that
+// the result can share a stream with the progress events.
+func (f *EventFormatter) Format(result *runner.Result) error {
+	output, err := NewSpecJSONFormatter(nil, f.config).Build(result)
+	if err != nil {
+		return err
+	}
+	return f.write(&Event{Event: EventResult, Result: output})
+}
+
+func (f *EventFormatter) write(event *Event) error {
+	event.Time = time.Now().Format(time.RFC3339Nano)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.encoder.Encode(event)
+}
+
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestEventFormatter(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Count = 2
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	f := NewEventFormatter(&buf, cfg)
+
+	if err := f.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	progress := []*runner.Progress{
+		{Index: 0, RequestID: "id-1", Status: "running", StartTime: start},
+		{Index: 0, RequestID: "id-1", Status: "completed", StatusCode: 201, StartTime: start, EndTime: start.Add(15 * time.Millisecond)},
+		{Index: 1, RequestID: "id-2", Status: "failed", Error: errors.New("connection refused"), StartTime: start, EndTime: start.Add(time.Millisecond)},
+	}
+	for _, p := range progress {
+		if err := f.FormatProgress(p); err != nil {
+			t.Fatalf("FormatProgress() error = %v", err)
+		}
+	}
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: start,
+		EndTime:   start.Add(20 * time.Millisecond),
+		Responses: []*client.Response{
+			{RequestIndex: 0, RequestID: "id-1", StatusCode: 201},
+			{RequestIndex: 1, RequestID: "id-2", Error: errors.New("connection refused")},
+		},
+	}
+	if err := f.Finish(result); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+
+	var events []Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line is not JSON: %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5", len(events))
+	}
+
+	tests := []struct {
+		event      string
+		index      int
+		status     string
+		statusCode int
+		err        string
+		durationMs int64
+	}{
+		{event: EventStart},
+		{event: EventProgress, index: 1, status: "running", durationMs: -1},
+		{event: EventProgress, index: 1, status: "completed", statusCode: 201, durationMs: 15},
+		{event: EventProgress, index: 2, status: "failed", err: "connection refused", durationMs: 1},
+		{event: EventSummary},
+	}
+	for i, tt := range tests {
+		e := events[i]
+		if e.Event != tt.event || e.Index != tt.index || e.Status != tt.status || e.StatusCode != tt.statusCode || e.Error != tt.err {
+			t.Errorf("events[%d] = %+v", i, e)
+		}
+		if e.Time == "" {
+			t.Errorf("events[%d] has no time", i)
+		}
+		if tt.event != EventProgress {
+			continue
+		}
+		switch {
+		case tt.durationMs < 0 && e.DurationMs != nil:
+			t.Errorf("events[%d] duration_ms = %d, want none", i, *e.DurationMs)
+		case tt.durationMs >= 0 && (e.DurationMs == nil || *e.DurationMs != tt.durationMs):
+			t.Errorf("events[%d] duration_ms = %v, want %d", i, e.DurationMs, tt.durationMs)
+		}
+	}
+
+	if events[0].URL != cfg.URL || events[0].Concurrent != 2 {
+		t.Errorf("start event = %+v", events[0])
+	}
+	summary := events[4].Summary
+	if summary == nil || summary.Total != 2 || summary.Successful != 1 || summary.Failed != 1 {
+		t.Errorf("summary event = %+v", summary)
+	}
+}
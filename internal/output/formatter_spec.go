@@ -1,12 +1,20 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/jsonpath"
 	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/terminal"
+	"github.com/shiroemons/conreq/internal/verify"
 )
 
 // SpecTextFormatter formats results as plain text according to the specification.
@@ -30,14 +38,20 @@ func (f *SpecTextFormatter) SetConfig(cfg interface{}) {
 //nolint:errcheck // io.Writer への出力エラーは無視
 func (f *SpecTextFormatter) Format(result *runner.Result) error {
 	// Request Summary
-	fmt.Fprintln(f.writer, "=== Request Summary ===")
+	fmt.Fprintln(f.writer, i18n.T("report.request_summary"))
 	fmt.Fprintf(f.writer, "URL: %s\n", result.Config.URL)
 	fmt.Fprintf(f.writer, "Method: %s\n", result.Config.Method)
 	fmt.Fprintf(f.writer, "Concurrent: %d\n", result.Config.Count)
 	fmt.Fprintf(f.writer, "Total Requests: %d\n", len(result.Responses))
 
 	// Results
-	fmt.Fprintln(f.writer, "\n=== Results ===")
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.results"))
+	colors := palette{enabled: terminal.ColorEnabled(result.Config.Color, f.writer)}
+	selectPaths, err := jsonpath.ParseAll(result.Config.Select)
+	if err != nil {
+		return err
+	}
+	latency := result.LatencyStats()
 
 	// インデックス順にソート
 	sortedResponses := make([]*client.Response, len(result.Responses))
@@ -49,33 +63,66 @@ func (f *SpecTextFormatter) Format(result *runner.Result) error {
 	for _, resp := range sortedResponses {
 		index := resp.RequestIndex + 1
 		timestamp := resp.Timestamp.Format("2006-01-02 15:04:05.000000")
+		trace := ""
+		for _, h := range result.Config.CorrelationHeaders {
+			trace += fmt.Sprintf(" | %s: %s", h.Name, resp.CorrelationIDs[h.Name])
+		}
+		if resp.TraceID != "" {
+			trace += fmt.Sprintf(" | Trace ID: %s | Span ID: %s", resp.TraceID, resp.SpanID)
+		}
+		if resp.Echo != "" {
+			trace += fmt.Sprintf(" | Echo: %s", resp.Echo)
+			if resp.Echo == client.EchoMismatched {
+				trace += fmt.Sprintf(" (received: %s)", resp.ReceivedRequestID)
+			}
+		}
+		if latency.IsOutlier(index) {
+			trace += " | Outlier"
+		}
 
 		if resp.Error != nil {
 			// エラーの場合
-			fmt.Fprintf(f.writer, "[%d] %s | Status: ERROR | Time: %dms | %s: %s\n",
+			fmt.Fprintf(f.writer, "[%d] %s | Status: %s | Time: %dms | %s: %s%s\n",
 				index,
 				timestamp,
+				colors.error("ERROR"),
 				resp.Duration.Milliseconds(),
 				result.Config.RequestIDHeader,
 				resp.RequestID,
+				trace,
 			)
-			fmt.Fprintf(f.writer, "Error: %v\n", resp.Error)
+			fmt.Fprintf(f.writer, "Error: %s\n", colors.error(resp.Error.Error()))
 		} else {
 			// 成功の場合
-			fmt.Fprintf(f.writer, "[%d] %s | Status: %d | Time: %dms | %s: %s\n",
+			fmt.Fprintf(f.writer, "[%d] %s | Status: %s | Time: %dms | %s: %s%s\n",
 				index,
 				timestamp,
-				resp.StatusCode,
+				colors.status(resp.StatusCode, fmt.Sprintf("%d", resp.StatusCode)),
 				resp.Duration.Milliseconds(),
 				result.Config.RequestIDHeader,
 				resp.RequestID,
+				trace,
 			)
 
-			// レスポンスボディ
-			if !result.Config.NoBody {
-				fmt.Fprintln(f.writer, resp.Body)
+			// レスポンスヘッダー
+			if result.Config.ShowHeaders {
+				writeHeaders(f.writer, resp.Headers)
+				fmt.Fprintln(f.writer)
+			}
+
+			// レスポンスボディ（--select指定時は選択した値のみ）
+			if len(selectPaths) > 0 {
+				f.formatSelected(resp.Body, selectPaths, colors)
+			} else if !result.Config.NoBody {
+				fmt.Fprintln(f.writer, colors.formatBody(resp.Body, resp.Headers.Get("Content-Type")))
 			} else {
-				fmt.Fprintln(f.writer, "[Body omitted]")
+				fmt.Fprintln(f.writer, i18n.T("report.body_omitted"))
+			}
+
+			// トレーラー
+			if result.Config.ShowHeaders && len(resp.Trailers) > 0 {
+				fmt.Fprintln(f.writer, i18n.T("report.trailers"))
+				writeHeaders(f.writer, resp.Trailers)
 			}
 		}
 
@@ -84,8 +131,12 @@ func (f *SpecTextFormatter) Format(result *runner.Result) error {
 		}
 	}
 
+	if result.Config.Timeline {
+		f.formatTimeline(result)
+	}
+
 	// Summary
-	fmt.Fprintln(f.writer, "\n=== Summary ===")
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.summary"))
 
 	successCount := result.SuccessCount()
 	errorCount := result.ErrorCount()
@@ -104,7 +155,7 @@ func (f *SpecTextFormatter) Format(result *runner.Result) error {
 	count4xx := result.Count4xx()
 	count5xx := result.Count5xx()
 
-	fmt.Fprintln(f.writer, "\n=== Status Code Breakdown ===")
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.status_breakdown"))
 	if count2xx > 0 {
 		fmt.Fprintf(f.writer, "2xx (Success): %d\n", count2xx)
 	}
@@ -131,5 +182,219 @@ func (f *SpecTextFormatter) Format(result *runner.Result) error {
 		fmt.Fprintf(f.writer, "Average Response Time: %dms\n", avgDuration.Milliseconds())
 	}
 
+	if err := f.formatLatency(latency); err != nil {
+		return err
+	}
+
+	if err := f.formatClusters(result); err != nil {
+		return err
+	}
+
+	if result.Config.VerifyRequestID {
+		f.formatEcho(result)
+	}
+
+	if result.Config.Diff {
+		if err := f.formatDiff(result); err != nil {
+			return err
+		}
+	}
+
+	if result.Config.Idempotency {
+		if err := f.formatIdempotency(result); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// writeHeaders prints headers sorted by name, one line per value so that
+// repeated headers such as Set-Cookie are all shown.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func writeHeaders(w io.Writer, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range h[key] {
+			fmt.Fprintf(w, "%s: %s\n", key, value)
+		}
+	}
+}
+
+// formatSelected prints the values selected from a JSON body, one line per
+// expression in the order they were given.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatSelected(body string, paths []*jsonpath.Path, colors palette) {
+	selected, isJSON := jsonpath.Select(body, paths)
+	if !isJSON {
+		fmt.Fprintln(f.writer, i18n.T("report.body_not_json"))
+		return
+	}
+	for _, p := range paths {
+		value, err := json.Marshal(selected[p.String()])
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(f.writer, "%s: %s\n", p, colors.highlightJSON(string(value)))
+	}
+}
+
+// formatEcho prints the request ID echo verification summary.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatEcho(result *runner.Result) {
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.echo_verification", result.Config.RequestIDHeader))
+
+	counts := result.EchoCounts()
+	for _, status := range client.EchoStatuses {
+		if counts[status] > 0 {
+			fmt.Fprintf(f.writer, "%s: %d\n", status, counts[status])
+		}
+	}
+
+	failures := result.EchoFailureCount()
+	if failures == 0 {
+		fmt.Fprintln(f.writer, i18n.T("report.all_echoed"))
+		return
+	}
+
+	fmt.Fprintf(f.writer, "Echo failures: %d/%d\n", failures, len(result.Responses))
+	for _, resp := range sortByIndex(result.Responses) {
+		switch resp.Echo {
+		case client.EchoMissing:
+			fmt.Fprintf(f.writer, "  [%d] missing: sent %s\n", resp.RequestIndex+1, resp.RequestID)
+		case client.EchoMismatched:
+			fmt.Fprintf(f.writer, "  [%d] mismatched: sent %s, received %s\n", resp.RequestIndex+1, resp.RequestID, resp.ReceivedRequestID)
+		}
+	}
+}
+
+// formatDiff prints the unified diffs of responses that deviate from the reference.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatDiff(result *runner.Result) error {
+	report, err := verify.Diff(result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.consistency"))
+	fmt.Fprintf(f.writer, "Reference: [%d]\n", report.Reference)
+	fmt.Fprintf(f.writer, "Identical: %s\n", formatIndices(report.Identical))
+	if report.Consistent() {
+		fmt.Fprintln(f.writer, i18n.T("report.all_consistent"))
+		return nil
+	}
+
+	deviating := make([]int, len(report.Deviations))
+	for i, d := range report.Deviations {
+		deviating[i] = d.Index
+	}
+	fmt.Fprintf(f.writer, "Deviating: %s\n", formatIndices(deviating))
+	for _, d := range report.Deviations {
+		fmt.Fprintln(f.writer)
+		fmt.Fprint(f.writer, d.Diff)
+	}
+	return nil
+}
+
+// formatIdempotency prints the idempotency-key verification verdict.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatIdempotency(result *runner.Result) error {
+	report, err := verify.CheckIdempotency(result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.idempotency"))
+	fmt.Fprintf(f.writer, "%s: %s\n", report.Header, report.Key)
+	if report.Reference > 0 {
+		fmt.Fprintln(f.writer, i18n.T("report.idempotency_reference", report.Reference))
+	}
+	fmt.Fprintln(f.writer, i18n.T("report.idempotency_replays", formatIndices(report.Replays)))
+	fmt.Fprintln(f.writer, i18n.T("report.idempotency_conflicts", formatIndices(report.Conflicts)))
+	for _, v := range report.Violations {
+		if v.Index > 0 {
+			fmt.Fprintf(f.writer, "  [%d] %s\n", v.Index, v.Reason)
+		} else {
+			fmt.Fprintf(f.writer, "  %s\n", v.Reason)
+		}
+	}
+	fmt.Fprintln(f.writer, i18n.T("report.idempotency_result", report.Verdict))
+	return nil
+}
+
+// clusterBodyPreview is the maximum length of a representative body in text mode.
+const clusterBodyPreview = 200
+
+// formatClusters prints the responses grouped by status code and body fingerprint
+// when they fall into more than one cluster.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatClusters(result *runner.Result) error {
+	clusters, err := result.Clusters()
+	if err != nil {
+		return err
+	}
+	// 全レスポンスが一致する場合は結果の繰り返しになるため表示しない
+	if len(clusters) < 2 {
+		return nil
+	}
+
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.clusters"))
+	w := tabwriter.NewWriter(f.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Cluster\tCount\tStatus\tBody Hash\tRequests")
+	for _, c := range clusters {
+		status, hash := fmt.Sprintf("%d", c.StatusCode), c.BodyHash
+		if c.Error != "" {
+			status, hash = "ERROR", "-"
+		}
+		fmt.Fprintf(w, "%s\t%d×\t%s\t%s\t%s\n", c.Label, c.Size(), status, hash, formatIndices(c.Indices))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// 各クラスタの代表レスポンス
+	for _, c := range clusters {
+		fmt.Fprintln(f.writer, "\n"+i18n.T("report.cluster_representative", c.Label, c.Indices[0]))
+		switch {
+		case c.Error != "":
+			fmt.Fprintf(f.writer, "Error: %s\n", c.Error)
+		case result.Config.NoBody:
+			fmt.Fprintln(f.writer, i18n.T("report.body_omitted"))
+		default:
+			fmt.Fprintln(f.writer, truncateString(c.Representative.Body, clusterBodyPreview))
+		}
+	}
+	return nil
+}
+
+func formatIndices(indices []int) string {
+	if len(indices) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = fmt.Sprintf("[%d]", index)
+	}
+	return strings.Join(parts, " ")
+}
+
+// sortByIndex returns a copy of responses ordered by request index.
+func sortByIndex(responses []*client.Response) []*client.Response {
+	sorted := make([]*client.Response, len(responses))
+	copy(sorted, responses)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].RequestIndex < sorted[j].RequestIndex
+	})
+	return sorted
+}
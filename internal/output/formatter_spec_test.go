@@ -0,0 +1,178 @@
+package output
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestSpecTextFormatterSelect(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Count = 2
+	cfg.Color = "never"
+	cfg.Select = []string{"$.order.status", ".order.version", "$.missing"}
+
+	jsonHeaders := http.Header{"Content-Type": []string{"application/json"}}
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: time.Now(),
+		EndTime:   time.Now(),
+		Responses: []*client.Response{
+			{RequestIndex: 0, StatusCode: 200, Headers: jsonHeaders, Body: `{"order":{"status":"paid","version":3,"items":["a"]}}`},
+			{RequestIndex: 1, StatusCode: 502, Body: "Bad Gateway"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSpecTextFormatter(&buf).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"$.order.status: \"paid\"\n.order.version: 3\n$.missing: null\n",
+		"[Body is not JSON]\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	results, _, _ := strings.Cut(got, "=== Summary ===")
+	if strings.Contains(results, `"items"`) {
+		t.Errorf("full body was printed despite --select:\n%s", results)
+	}
+}
+
+func TestSpecTextFormatterCorrelationAndTrace(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Color = "never"
+	cfg.TracePropagation = "w3c"
+	cfg.CorrelationHeaders = []config.CorrelationHeader{{Name: "Idempotency-Key", Shared: true}}
+
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: time.Now(),
+		EndTime:   time.Now(),
+		Responses: []*client.Response{{
+			RequestIndex:   0,
+			StatusCode:     200,
+			RequestID:      "req-1",
+			CorrelationIDs: map[string]string{"Idempotency-Key": "key-1"},
+			TraceID:        "4bf92f3577b34da6a3ce929d0e0e4736",
+			SpanID:         "00f067aa0ba902b7",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSpecTextFormatter(&buf).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	for _, want := range []string{
+		" | Idempotency-Key: key-1",
+		" | Trace ID: 4bf92f3577b34da6a3ce929d0e0e4736 | Span ID: 00f067aa0ba902b7",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestSpecTextFormatterClusters(t *testing.T) {
+	tests := []struct {
+		name   string
+		bodies []string
+		want   bool
+	}{
+		{"single request", []string{`{"id":1}`}, false},
+		{"identical responses", []string{`{"id":1}`, `{"id": 1}`}, false},
+		{"different responses", []string{`{"id":1}`, `{"id":2}`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.URL = "https://example.com"
+			cfg.Count = len(tt.bodies)
+			cfg.Color = "never"
+
+			result := &runner.Result{Config: cfg, StartTime: time.Now(), EndTime: time.Now()}
+			for i, body := range tt.bodies {
+				result.Responses = append(result.Responses, &client.Response{RequestIndex: i, StatusCode: 200, Body: body})
+			}
+
+			var buf bytes.Buffer
+			if err := NewSpecTextFormatter(&buf).Format(result); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := strings.Contains(buf.String(), "=== Response Clusters ==="); got != tt.want {
+				t.Errorf("clusters shown = %v, want %v:\n%s", got, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestSpecTextFormatterWithoutLanguage(t *testing.T) {
+	// ロケール未設定時と同じく言語を選択しない状態で、見出しが従来の英語のままであること
+	if err := i18n.SetLanguage(""); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.SetLanguage(i18n.English) })
+
+	var buf bytes.Buffer
+	if err := NewSpecTextFormatter(&buf).Format(latencyResult()); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{"=== Request Summary ===", "=== Results ===", "=== Summary ===", "=== Latency ==="} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestSpecTextFormatterIdempotencyLanguage(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{lang: i18n.English, want: []string{"Reference: [1]\n", "Replays: -\n", "  [2] body differs from reference #1\n", "Result: FAIL\n"}},
+		{lang: i18n.Japanese, want: []string{"基準: [1]\n", "リプレイ: -\n", "  [2] ボディが基準 #1 と異なります\n", "判定: FAIL\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if err := i18n.SetLanguage(tt.lang); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = i18n.SetLanguage(i18n.English) })
+
+			cfg := config.NewConfig()
+			cfg.URL = "https://example.com"
+			cfg.Count = 2
+			cfg.Color = "never"
+			cfg.Idempotency = true
+			result := &runner.Result{Config: cfg, StartTime: start, EndTime: start, Responses: []*client.Response{
+				{RequestIndex: 0, StatusCode: 200, Body: `{"id":1}`, Timestamp: start, Duration: time.Millisecond},
+				{RequestIndex: 1, StatusCode: 200, Body: `{"id":2}`, Timestamp: start, Duration: 2 * time.Millisecond},
+			}}
+
+			var buf bytes.Buffer
+			if err := NewSpecTextFormatter(&buf).Format(result); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// HARVersion is the HAR specification version written by HARFormatter.
+const HARVersion = "1.2"
+
+// HARFormatter formats results as an HTTP Archive (HAR 1.2) that can be
+// opened in browser devtools and HAR viewers.
+type HARFormatter struct {
+	writer  io.Writer
+	config  *config.Config
+	version string
+}
+
+// NewHARFormatter creates a new HAR formatter. version is recorded as the
+// creator version of the archive.
+func NewHARFormatter(w io.Writer, cfg *config.Config, version string) *HARFormatter {
+	return &HARFormatter{writer: w, config: cfg, version: version}
+}
+
+// HAR is the root object of an HTTP Archive.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog represents the log object.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator represents the application that created the archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry represents a single request and its response.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Conreq          HARConreq   `json:"_conreq"`
+}
+
+// HARRequest represents the request of an entry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARCookie    `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse represents the response of an entry.
+// Failed requests have status 0 and the error in _error.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARCookie    `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	Error       string         `json:"_error,omitempty"`
+}
+
+// HARNameValue is a header or query string parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARCookie represents a request or response cookie.
+type HARCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+// HARPostData represents the request body.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent represents the response body.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// HARTimings holds the phase durations in milliseconds; -1 means not applicable.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// HARConreq holds conreq specific data for an entry.
+type HARConreq struct {
+	Index          int               `json:"index"` // 1から始まるリクエスト番号
+	RequestID      string            `json:"requestId"`
+	CorrelationIDs map[string]string `json:"correlationIds,omitempty"`
+	TraceID        string            `json:"traceId,omitempty"`
+	SpanID         string            `json:"spanId,omitempty"`
+	Error          string            `json:"error,omitempty"`
+}
+
+// Format writes the result as a HAR document.
+func (f *HARFormatter) Format(result *runner.Result) error {
+	har := HAR{
+		Log: HARLog{
+			Version: HARVersion,
+			Creator: HARCreator{Name: "conreq", Version: f.version},
+			Entries: make([]HAREntry, 0, len(result.Responses)),
+		},
+	}
+	for _, resp := range sortByIndex(result.Responses) {
+		har.Log.Entries = append(har.Log.Entries, f.entry(resp))
+	}
+
+	encoder := json.NewEncoder(f.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(har)
+}
+
+func (f *HARFormatter) entry(resp *client.Response) HAREntry {
+	httpVersion := resp.Proto
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
+
+	entry := HAREntry{
+		StartedDateTime: resp.Timestamp.Format(time.RFC3339Nano),
+		Time:            milliseconds(resp.Duration),
+		Request: HARRequest{
+			Method:      f.config.Method,
+			URL:         f.config.URL,
+			HTTPVersion: httpVersion,
+			Cookies:     harCookies((&http.Request{Header: resp.RequestHeaders}).Cookies()),
+			Headers:     harHeaders(resp.RequestHeaders),
+			QueryString: harQueryString(f.config.URL),
+			HeadersSize: -1,
+			BodySize:    len(resp.RequestBody),
+		},
+		Response: HARResponse{
+			Status:      resp.StatusCode,
+			StatusText:  resp.StatusText,
+			HTTPVersion: httpVersion,
+			Cookies:     harCookies((&http.Response{Header: resp.Headers}).Cookies()),
+			Headers:     harHeaders(resp.Headers),
+			Content: HARContent{
+				Size:     len(resp.Body),
+				MimeType: resp.Headers.Get("Content-Type"),
+				Text:     resp.Body,
+			},
+			RedirectURL: resp.Headers.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(resp.Body),
+		},
+		Timings: harTimings(resp),
+		Conreq: HARConreq{
+			Index:          resp.RequestIndex + 1,
+			RequestID:      resp.RequestID,
+			CorrelationIDs: resp.CorrelationIDs,
+			TraceID:        resp.TraceID,
+			SpanID:         resp.SpanID,
+		},
+	}
+
+	if resp.RequestBody != "" {
+		entry.Request.PostData = &HARPostData{
+			MimeType: resp.RequestHeaders.Get("Content-Type"),
+			Text:     resp.RequestBody,
+		}
+	}
+	if resp.Error != nil {
+		entry.Response.Error = resp.Error.Error()
+		entry.Response.BodySize = -1
+		entry.Conreq.Error = resp.Error.Error()
+	}
+	if resp.Timings != nil {
+		// 全体時間はボディ受信完了までのフェーズの合計にする
+		t := entry.Timings
+		entry.Time = 0
+		for _, d := range []float64{t.Blocked, t.DNS, t.Connect, t.Send, t.Wait, t.Receive} {
+			entry.Time += max(d, 0)
+		}
+	}
+	return entry
+}
+
+func harTimings(resp *client.Response) HARTimings {
+	t := resp.Timings
+	if t == nil {
+		// フェーズが計測できなかった場合は待ち時間として扱う
+		return HARTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Wait: milliseconds(resp.Duration)}
+	}
+	return HARTimings{
+		Blocked: optionalMilliseconds(t.Blocked),
+		DNS:     optionalMilliseconds(t.DNS),
+		Connect: optionalMilliseconds(t.Connect),
+		Send:    milliseconds(t.Send),
+		Wait:    milliseconds(t.Wait),
+		Receive: milliseconds(t.Receive),
+		SSL:     optionalMilliseconds(t.TLSHandshake),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func optionalMilliseconds(d time.Duration) float64 {
+	if d < 0 {
+		return -1
+	}
+	return milliseconds(d)
+}
+
+// harHeaders converts headers to name/value pairs sorted by name.
+func harHeaders(h http.Header) []HARNameValue {
+	headers := make([]HARNameValue, 0, len(h))
+	for key, values := range h {
+		for _, value := range values {
+			headers = append(headers, HARNameValue{Name: key, Value: value})
+		}
+	}
+	sort.SliceStable(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+	return headers
+}
+
+func harQueryString(rawURL string) []HARNameValue {
+	params := make([]HARNameValue, 0)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return params
+	}
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range query[key] {
+			params = append(params, HARNameValue{Name: key, Value: value})
+		}
+	}
+	return params
+}
+
+func harCookies(cookies []*http.Cookie) []HARCookie {
+	result := make([]HARCookie, 0, len(cookies))
+	for _, c := range cookies {
+		cookie := HARCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.Format(time.RFC3339)
+		}
+		result = append(result, cookie)
+	}
+	return result
+}
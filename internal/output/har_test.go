@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestHARFormatter(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com/orders?page=2"
+	cfg.Method = http.MethodPost
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: start,
+		EndTime:   start.Add(time.Second),
+		Responses: []*client.Response{
+			{
+				RequestIndex:   1,
+				RequestID:      "id-2",
+				RequestHeaders: http.Header{"X-Request-Id": {"id-2"}},
+				Error:          errors.New("connection refused"),
+				Timestamp:      start,
+				Duration:       5 * time.Millisecond,
+			},
+			{
+				RequestIndex: 0,
+				RequestID:    "id-1",
+				RequestHeaders: http.Header{
+					"X-Request-Id": {"id-1"},
+					"Content-Type": {"application/json"},
+					"Cookie":       {"session=abc"},
+				},
+				RequestBody: `{"id":"id-1"}`,
+				StatusCode:  201,
+				StatusText:  "Created",
+				Proto:       "HTTP/1.1",
+				Headers: http.Header{
+					"Content-Type": {"application/json"},
+					"Set-Cookie":   {"token=xyz; Path=/; HttpOnly"},
+				},
+				Body:      `{"ok":true}`,
+				Timestamp: start,
+				Duration:  30 * time.Millisecond,
+				Timings: &client.Timings{
+					Blocked:      time.Millisecond,
+					DNS:          -1,
+					Connect:      3 * time.Millisecond,
+					TLSHandshake: -1,
+					Send:         time.Millisecond,
+					Wait:         20 * time.Millisecond,
+					Receive:      5 * time.Millisecond,
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHARFormatter(&buf, cfg, "v1.2.3").Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var har HAR
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if har.Log.Version != "1.2" || har.Log.Creator.Name != "conreq" || har.Log.Creator.Version != "v1.2.3" {
+		t.Errorf("log = %+v %+v", har.Log.Version, har.Log.Creator)
+	}
+	if len(har.Log.Entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(har.Log.Entries))
+	}
+
+	ok := har.Log.Entries[0]
+	if ok.Conreq.Index != 1 || ok.Conreq.RequestID != "id-1" {
+		t.Errorf("_conreq = %+v", ok.Conreq)
+	}
+	if ok.Request.Method != http.MethodPost || len(ok.Request.QueryString) != 1 || ok.Request.QueryString[0].Value != "2" {
+		t.Errorf("request = %+v", ok.Request)
+	}
+	if ok.Request.PostData == nil || ok.Request.PostData.Text != `{"id":"id-1"}` || ok.Request.PostData.MimeType != "application/json" {
+		t.Errorf("postData = %+v", ok.Request.PostData)
+	}
+	if len(ok.Request.Cookies) != 1 || ok.Request.Cookies[0].Name != "session" {
+		t.Errorf("request cookies = %+v", ok.Request.Cookies)
+	}
+	if len(ok.Response.Cookies) != 1 || ok.Response.Cookies[0].Name != "token" || !ok.Response.Cookies[0].HTTPOnly {
+		t.Errorf("response cookies = %+v", ok.Response.Cookies)
+	}
+	if ok.Response.Status != 201 || ok.Response.Content.Text != `{"ok":true}` || ok.Response.Content.Size != 11 {
+		t.Errorf("response = %+v", ok.Response)
+	}
+	wantTimings := HARTimings{Blocked: 1, DNS: -1, Connect: 3, Send: 1, Wait: 20, Receive: 5, SSL: -1}
+	if ok.Timings != wantTimings {
+		t.Errorf("timings = %+v, want %+v", ok.Timings, wantTimings)
+	}
+	if ok.Time != 30 {
+		t.Errorf("time = %v, want 30", ok.Time)
+	}
+
+	failed := har.Log.Entries[1]
+	if failed.Response.Status != 0 || failed.Response.Error != "connection refused" || failed.Conreq.Error != "connection refused" {
+		t.Errorf("failed response = %+v, _conreq = %+v", failed.Response, failed.Conreq)
+	}
+	if failed.Timings.Wait != 5 || failed.Timings.Connect != -1 {
+		t.Errorf("failed timings = %+v", failed.Timings)
+	}
+}
@@ -0,0 +1,124 @@
+package output
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplate string
+
+var htmlReport = template.Must(template.New("report").Parse(htmlReportTemplate))
+
+// HTMLFormatter formats results as a single self-contained HTML report with
+// the summary, a timeline of the requests and expandable request and response
+// details. CSS and JavaScript are embedded so the file can be shared as is.
+type HTMLFormatter struct {
+	writer io.Writer
+	config *config.Config
+}
+
+// NewHTMLFormatter creates a new HTML report formatter.
+func NewHTMLFormatter(w io.Writer, cfg *config.Config) *HTMLFormatter {
+	return &HTMLFormatter{writer: w, config: cfg}
+}
+
+// htmlReportData is the data passed to the report template.
+type htmlReportData struct {
+	*SpecJSONOutput
+	Method          string
+	URL             string
+	RequestIDHeader string
+	TimelineMs      int64
+	Rows            []htmlRow
+}
+
+// htmlRow is a request shown in the timeline and the details list.
+type htmlRow struct {
+	Index           int
+	RequestID       string
+	StatusLabel     string
+	StatusClass     string
+	Error           string
+	StartedAt       string
+	CompletedAt     string
+	OffsetMs        int64
+	DurationMs      int64
+	LeftPercent     string
+	WidthPercent    string
+	RequestHeaders  []HARNameValue
+	RequestBody     string
+	ResponseHeaders []HARNameValue
+	ResponseBody    string
+}
+
+// Format writes the result as an HTML report.
+func (f *HTMLFormatter) Format(result *runner.Result) error {
+	spec, err := NewSpecJSONFormatter(nil, f.config).Build(result)
+	if err != nil {
+		return err
+	}
+
+	data := htmlReportData{
+		SpecJSONOutput:  spec,
+		Method:          f.config.Method,
+		URL:             f.config.URL,
+		RequestIDHeader: f.config.RequestIDHeader,
+	}
+
+	// タイムラインの範囲は実行開始から最後のレスポンス完了まで
+	origin := result.StartTime
+	span := result.EndTime.Sub(origin)
+	for _, resp := range result.Responses {
+		if resp.Timestamp.Before(origin) {
+			origin = resp.Timestamp
+		}
+		span = max(span, resp.Timestamp.Add(resp.Duration).Sub(origin))
+	}
+	if span <= 0 {
+		span = time.Millisecond
+	}
+	data.TimelineMs = span.Milliseconds()
+
+	for _, resp := range sortByIndex(result.Responses) {
+		offset := resp.Timestamp.Sub(origin)
+		row := htmlRow{
+			Index:           resp.RequestIndex + 1,
+			RequestID:       resp.RequestID,
+			StartedAt:       resp.Timestamp.Format("15:04:05.000000"),
+			CompletedAt:     resp.Timestamp.Add(resp.Duration).Format("15:04:05.000000"),
+			OffsetMs:        offset.Milliseconds(),
+			DurationMs:      resp.Duration.Milliseconds(),
+			LeftPercent:     percent(offset, span),
+			WidthPercent:    percent(resp.Duration, span),
+			RequestHeaders:  harHeaders(resp.RequestHeaders),
+			RequestBody:     resp.RequestBody,
+			ResponseHeaders: harHeaders(resp.Headers),
+			ResponseBody:    resp.Body,
+		}
+		if resp.Error != nil {
+			row.StatusLabel = "ERROR"
+			row.StatusClass = "serr"
+			row.Error = resp.Error.Error()
+		} else {
+			row.StatusLabel = fmt.Sprintf("%d %s", resp.StatusCode, resp.StatusText)
+			row.StatusClass = fmt.Sprintf("s%dxx", resp.StatusCode/100)
+		}
+		if f.config.NoBody {
+			row.ResponseBody = "[Body omitted]"
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	return htmlReport.Execute(f.writer, data)
+}
+
+func percent(d, total time.Duration) string {
+	return fmt.Sprintf("%.3f", float64(d)/float64(total)*100)
+}
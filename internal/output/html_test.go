@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestHTMLFormatter(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com/orders"
+	cfg.Count = 2
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: start,
+		EndTime:   start.Add(100 * time.Millisecond),
+		Responses: []*client.Response{
+			{
+				RequestIndex: 0,
+				RequestID:    "id-1",
+				StatusCode:   201,
+				StatusText:   "Created",
+				Headers:      http.Header{"Content-Type": {"text/html"}},
+				Body:         `<script>alert(1)</script>`,
+				Timestamp:    start,
+				Duration:     50 * time.Millisecond,
+			},
+			{
+				RequestIndex: 1,
+				RequestID:    "id-2",
+				Error:        context.DeadlineExceeded,
+				Timestamp:    start.Add(25 * time.Millisecond),
+				Duration:     75 * time.Millisecond,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLFormatter(&buf, cfg).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	out := buf.String()
+
+	wants := []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"<script>",
+		`id="request-1"`,
+		`id="request-2"`,
+		// タイムライン上の位置と長さは実行時間に対する割合
+		`left: 0.000%; width: 50.000%`,
+		`left: 25.000%; width: 75.000%`,
+		"201 Created",
+		"context deadline exceeded",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+	}
+	for _, want := range wants {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+
+	// 外部リソースを参照しない単一ファイルであること
+	for _, external := range []string{"<link", "src=", "http://", "https://cdn"} {
+		if strings.Contains(out, external) {
+			t.Errorf("output references external resources: %q", external)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"encoding/json"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/shiroemons/conreq/internal/runner"
@@ -20,14 +21,19 @@ func NewJSONFormatter(w io.Writer) *JSONFormatter {
 
 // JSONResponse represents a single HTTP response in JSON format.
 type JSONResponse struct {
-	RequestID    string            `json:"request_id"`
-	StatusCode   int               `json:"status_code,omitempty"`
-	Headers      map[string]string `json:"headers,omitempty"`
-	Body         string            `json:"body,omitempty"`
-	Duration     string            `json:"duration"`
-	Timestamp    string            `json:"timestamp"`
-	RequestIndex int               `json:"request_index"`
-	Error        string            `json:"error,omitempty"`
+	RequestID         string              `json:"request_id"`
+	ReceivedRequestID string              `json:"received_request_id,omitempty"`
+	CorrelationIDs    map[string]string   `json:"correlation_ids,omitempty"`
+	TraceID           string              `json:"trace_id,omitempty"`
+	SpanID            string              `json:"span_id,omitempty"`
+	StatusCode        int                 `json:"status_code,omitempty"`
+	Headers           map[string][]string `json:"headers,omitempty"`
+	Trailers          map[string][]string `json:"trailers,omitempty"`
+	Body              string              `json:"body,omitempty"`
+	Duration          string              `json:"duration"`
+	Timestamp         string              `json:"timestamp"`
+	RequestIndex      int                 `json:"request_index"`
+	Error             string              `json:"error,omitempty"`
 }
 
 // JSONResult represents the overall result in JSON format.
@@ -42,6 +48,16 @@ type JSONResult struct {
 	Responses    []JSONResponse `json:"responses"`
 }
 
+// headerValues converts http.Header into a map that keeps every value of
+// repeated headers such as Set-Cookie, Vary and Link.
+func headerValues(h http.Header) map[string][]string {
+	values := make(map[string][]string, len(h))
+	for key, v := range h {
+		values[key] = append([]string(nil), v...)
+	}
+	return values
+}
+
 // Format formats the result as JSON.
 func (f *JSONFormatter) Format(result *runner.Result) error {
 	jsonResult := JSONResult{
@@ -60,10 +76,14 @@ func (f *JSONFormatter) Format(result *runner.Result) error {
 
 	for _, resp := range result.Responses {
 		jsonResp := JSONResponse{
-			RequestID:    resp.RequestID,
-			Duration:     resp.Duration.String(),
-			Timestamp:    resp.Timestamp.Format(time.RFC3339Nano),
-			RequestIndex: resp.RequestIndex,
+			RequestID:         resp.RequestID,
+			ReceivedRequestID: resp.ReceivedRequestID,
+			CorrelationIDs:    resp.CorrelationIDs,
+			TraceID:           resp.TraceID,
+			SpanID:            resp.SpanID,
+			Duration:          resp.Duration.String(),
+			Timestamp:         resp.Timestamp.Format(time.RFC3339Nano),
+			RequestIndex:      resp.RequestIndex,
 		}
 
 		if resp.Error != nil {
@@ -71,12 +91,9 @@ func (f *JSONFormatter) Format(result *runner.Result) error {
 		} else {
 			jsonResp.StatusCode = resp.StatusCode
 			jsonResp.Body = resp.Body
-
-			jsonResp.Headers = make(map[string]string)
-			for key, values := range resp.Headers {
-				if len(values) > 0 {
-					jsonResp.Headers[key] = values[0]
-				}
+			jsonResp.Headers = headerValues(resp.Headers)
+			if len(resp.Trailers) > 0 {
+				jsonResp.Trailers = headerValues(resp.Trailers)
 			}
 		}
 
@@ -2,15 +2,20 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/shiroemons/conreq/internal/client"
 	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/jsonpath"
 	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/tracing"
+	"github.com/shiroemons/conreq/internal/verify"
 )
 
 // SpecJSONFormatter formats results as JSON according to the specification.
@@ -45,22 +50,31 @@ type SpecJSONRequest struct {
 
 // SpecJSONResponse represents a response in the JSON output.
 type SpecJSONResponse struct {
-	StatusCode int               `json:"status_code"`
-	StatusText string            `json:"status_text"`
-	Headers    map[string]string `json:"headers"`
-	Body       string            `json:"body"`
+	StatusCode int                 `json:"status_code"`
+	StatusText string              `json:"status_text"`
+	Headers    map[string][]string `json:"headers"`
+	Trailers   map[string][]string `json:"trailers,omitempty"`
+	Body       string              `json:"body"`
 }
 
 // SpecJSONResult represents a single result in the JSON output.
 type SpecJSONResult struct {
-	Index       int               `json:"index"`
-	RequestID   string            `json:"request_id"`
-	StartedAt   string            `json:"started_at"`
-	CompletedAt string            `json:"completed_at"`
-	DurationMs  int64             `json:"duration_ms"`
-	Request     SpecJSONRequest   `json:"request"`
-	Response    *SpecJSONResponse `json:"response"`
-	Error       interface{}       `json:"error"`
+	Index             int               `json:"index"`
+	RequestID         string            `json:"request_id"`
+	ReceivedRequestID string            `json:"received_request_id,omitempty"` // サーバーがエコーしたID
+	RequestIDEcho     string            `json:"request_id_echo,omitempty"`     // --verify-request-id の検証結果
+	CorrelationIDs    map[string]string `json:"correlation_ids,omitempty"`     // 追加の相関ヘッダーの値
+	TraceID           string            `json:"trace_id,omitempty"`
+	SpanID            string            `json:"span_id,omitempty"`
+	StartedAt         string            `json:"started_at"`
+	CompletedAt       string            `json:"completed_at"`
+	DurationMs        int64             `json:"duration_ms"`
+	Request           SpecJSONRequest   `json:"request"`
+	Response          *SpecJSONResponse `json:"response"`
+	Selected          map[string]any    `json:"selected,omitempty"` // --select で抽出した値
+	Outlier           bool              `json:"outlier,omitempty"`  // 応答時間が外れ値か
+	Error             interface{}       `json:"error"`
+	TimeoutPhase      string            `json:"timeout_phase,omitempty"` // connect, tls_handshake, response_header, body
 }
 
 // SpecJSONSummary represents the summary in the JSON output.
@@ -80,20 +94,171 @@ type SpecJSONSummary struct {
 		Count5xx      int `json:"5xx"`
 		NetworkErrors int `json:"network_errors"`
 	} `json:"status_code_breakdown"`
+	Latency       *SpecJSONLatency     `json:"latency,omitempty"`
+	RequestIDEcho *SpecJSONEchoSummary `json:"request_id_echo,omitempty"`
+}
+
+// SpecJSONLatency represents the response time statistics of the requests
+// that received a response. Durations are in milliseconds.
+type SpecJSONLatency struct {
+	Count     int                       `json:"count"`
+	MinMs     float64                   `json:"min_ms"`
+	MaxMs     float64                   `json:"max_ms"`
+	MeanMs    float64                   `json:"mean_ms"`
+	StdDevMs  float64                   `json:"stddev_ms"`
+	P50Ms     float64                   `json:"p50_ms"`
+	P90Ms     float64                   `json:"p90_ms"`
+	P95Ms     float64                   `json:"p95_ms"`
+	P99Ms     float64                   `json:"p99_ms"`
+	Outliers  []int                     `json:"outliers"`
+	Histogram []SpecJSONHistogramBucket `json:"histogram"`
+}
+
+// SpecJSONHistogramBucket represents the number of response times in [lower_ms, upper_ms).
+type SpecJSONHistogramBucket struct {
+	LowerMs float64 `json:"lower_ms"`
+	UpperMs float64 `json:"upper_ms"`
+	Count   int     `json:"count"`
+}
+
+// SpecJSONEchoSummary represents the request ID echo verification summary.
+type SpecJSONEchoSummary struct {
+	Header      string                 `json:"header"`
+	Matched     int                    `json:"matched"`
+	FoundInBody int                    `json:"found_in_body"`
+	Missing     int                    `json:"missing"`
+	Mismatched  int                    `json:"mismatched"`
+	Failures    []SpecJSONEchoMismatch `json:"failures"`
+}
+
+// SpecJSONEchoMismatch describes a response whose request ID echo was missing or mismatched.
+type SpecJSONEchoMismatch struct {
+	Index    int    `json:"index"`
+	Status   string `json:"status"`
+	Sent     string `json:"sent"`
+	Received string `json:"received"`
+}
+
+// SpecJSONIdempotency represents the idempotency-key verification verdict.
+type SpecJSONIdempotency struct {
+	Verdict    string              `json:"verdict"`
+	Header     string              `json:"header"`
+	Key        string              `json:"key"`
+	Reference  int                 `json:"reference"`
+	Replays    []int               `json:"replays"`
+	Conflicts  []int               `json:"conflicts"`
+	Violations []SpecJSONViolation `json:"violations"`
+}
+
+// SpecJSONViolation represents a single verification violation.
+type SpecJSONViolation struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// SpecJSONConsistency represents the cross-response diff report.
+type SpecJSONConsistency struct {
+	Reference  int                 `json:"reference"`
+	Consistent bool                `json:"consistent"`
+	Identical  []int               `json:"identical"`
+	Deviations []SpecJSONDeviation `json:"deviations"`
+}
+
+// SpecJSONDeviation represents a response that differs from the reference.
+type SpecJSONDeviation struct {
+	Index int    `json:"index"`
+	Diff  string `json:"diff"`
+}
+
+// SpecJSONCluster represents a group of responses with the same status code and body fingerprint.
+type SpecJSONCluster struct {
+	Label              string `json:"label"`
+	Count              int    `json:"count"`
+	Status             int    `json:"status,omitempty"`
+	Error              string `json:"error,omitempty"`
+	BodyHash           string `json:"body_hash,omitempty"`
+	Indices            []int  `json:"indices"`
+	RepresentativeBody string `json:"representative_body,omitempty"`
 }
 
 // SpecJSONOutput represents the complete JSON output structure.
+//
+// Its shape is versioned by SchemaVersion and described by Schema; any change
+// to the fields of the SpecJSON types requires a new schema version.
 type SpecJSONOutput struct {
-	Metadata SpecJSONMetadata `json:"metadata"`
-	Results  []SpecJSONResult `json:"results"`
-	Summary  SpecJSONSummary  `json:"summary"`
+	SchemaVersion string               `json:"schema_version"`
+	Metadata      SpecJSONMetadata     `json:"metadata"`
+	Results       []SpecJSONResult     `json:"results"`
+	Summary       SpecJSONSummary      `json:"summary"`
+	Clusters      []SpecJSONCluster    `json:"clusters"`
+	Consistency   *SpecJSONConsistency `json:"consistency,omitempty"`
+	Idempotency   *SpecJSONIdempotency `json:"idempotency,omitempty"`
 }
 
 // Format formats the result as JSON according to the specification.
+func (f *SpecJSONFormatter) Format(result *runner.Result) error {
+	output, err := f.Build(result)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// sentRequest returns the headers and the body sent for resp, with the
+// placeholders expanded. When the request could not be created, they are
+// rebuilt from the configuration.
+func (f *SpecJSONFormatter) sentRequest(resp *client.Response) (map[string]string, interface{}) {
+	if resp.RequestHeaders == nil {
+		return f.configuredRequest(resp)
+	}
+
+	headers := make(map[string]string, len(resp.RequestHeaders))
+	for key, values := range resp.RequestHeaders {
+		headers[key] = strings.Join(values, ", ")
+	}
+	var body interface{}
+	if resp.RequestBody != "" {
+		body = resp.RequestBody
+	}
+	return headers, body
+}
+
+// configuredRequest rebuilds the request headers and body of resp from the
+// configuration.
+func (f *SpecJSONFormatter) configuredRequest(resp *client.Response) (map[string]string, interface{}) {
+	headers := make(map[string]string)
+	for key, value := range f.config.Headers {
+		headers[key] = value
+	}
+	if resp.RequestID != "" {
+		headers[f.config.RequestIDHeader] = resp.RequestID
+	}
+	for key, value := range resp.CorrelationIDs {
+		headers[key] = value
+	}
+	for key, value := range tracing.Headers(f.config.TracePropagation, resp.TraceID, resp.SpanID, f.config.TraceState) {
+		headers[key] = value
+	}
+	if f.config.Body != "" && headers["Content-Type"] == "" {
+		headers["Content-Type"] = "application/json"
+	}
+
+	var body interface{}
+	if f.config.Body != "" {
+		body = f.config.Body
+	}
+	return headers, body
+}
+
+// Build converts the result into the JSON output structure without writing it.
 //
 //nolint:funlen // 仕様に従った複雑な出力のため
-func (f *SpecJSONFormatter) Format(result *runner.Result) error {
-	output := SpecJSONOutput{
+func (f *SpecJSONFormatter) Build(result *runner.Result) (*SpecJSONOutput, error) {
+	output := &SpecJSONOutput{
+		SchemaVersion: SchemaVersion,
 		Metadata: SpecJSONMetadata{
 			URL:             f.config.URL,
 			Method:          f.config.Method,
@@ -106,6 +271,12 @@ func (f *SpecJSONFormatter) Format(result *runner.Result) error {
 		Results: make([]SpecJSONResult, 0, len(result.Responses)),
 	}
 
+	selectPaths, err := jsonpath.ParseAll(f.config.Select)
+	if err != nil {
+		return nil, err
+	}
+	latency := result.LatencyStats()
+
 	// ソートしてインデックス順に処理
 	sortedResponses := make([]*client.Response, len(result.Responses))
 	copy(sortedResponses, result.Responses)
@@ -119,30 +290,19 @@ func (f *SpecJSONFormatter) Format(result *runner.Result) error {
 	firstSuccess := true
 
 	for _, resp := range sortedResponses {
-		headers := make(map[string]string)
-		for key, value := range f.config.Headers {
-			headers[key] = value
-		}
-		if resp.RequestID != "" {
-			headers[f.config.RequestIDHeader] = resp.RequestID
-		}
-		if f.config.Body != "" && headers["Content-Type"] == "" {
-			headers["Content-Type"] = "application/json"
-		}
-
-		var body interface{}
-		if f.config.Body != "" {
-			body = f.config.Body
-		} else {
-			body = nil
-		}
+		headers, body := f.sentRequest(resp)
 
 		result := SpecJSONResult{
-			Index:       resp.RequestIndex + 1,
-			RequestID:   resp.RequestID,
-			StartedAt:   resp.Timestamp.Format(time.RFC3339Nano),
-			CompletedAt: resp.Timestamp.Add(resp.Duration).Format(time.RFC3339Nano),
-			DurationMs:  resp.Duration.Milliseconds(),
+			Index:             resp.RequestIndex + 1,
+			RequestID:         resp.RequestID,
+			ReceivedRequestID: resp.ReceivedRequestID,
+			RequestIDEcho:     string(resp.Echo),
+			CorrelationIDs:    resp.CorrelationIDs,
+			TraceID:           resp.TraceID,
+			SpanID:            resp.SpanID,
+			StartedAt:         resp.Timestamp.Format(time.RFC3339Nano),
+			CompletedAt:       resp.Timestamp.Add(resp.Duration).Format(time.RFC3339Nano),
+			DurationMs:        resp.Duration.Milliseconds(),
 			Request: SpecJSONRequest{
 				Method:  f.config.Method,
 				URL:     f.config.URL,
@@ -160,6 +320,10 @@ func (f *SpecJSONFormatter) Format(result *runner.Result) error {
 			} else {
 				result.Error = resp.Error.Error()
 			}
+			var timeoutErr *client.TimeoutError
+			if errors.As(resp.Error, &timeoutErr) {
+				result.TimeoutPhase = timeoutErr.Phase
+			}
 		} else {
 			// 成功の場合
 			statusText := http.StatusText(resp.StatusCode)
@@ -167,19 +331,19 @@ func (f *SpecJSONFormatter) Format(result *runner.Result) error {
 				statusText = "Unknown"
 			}
 
-			respHeaders := make(map[string]string)
-			for key, values := range resp.Headers {
-				if len(values) > 0 {
-					respHeaders[key] = values[0]
-				}
-			}
-
 			result.Response = &SpecJSONResponse{
 				StatusCode: resp.StatusCode,
 				StatusText: statusText,
-				Headers:    respHeaders,
+				Headers:    headerValues(resp.Headers),
 				Body:       resp.Body,
 			}
+			if len(resp.Trailers) > 0 {
+				result.Response.Trailers = headerValues(resp.Trailers)
+			}
+			if len(selectPaths) > 0 {
+				result.Selected, _ = jsonpath.Select(resp.Body, selectPaths)
+			}
+			result.Outlier = latency.IsOutlier(result.Index)
 
 			statusCode := resp.StatusCode
 			statusCodeStr := fmt.Sprintf("%d", statusCode)
@@ -236,8 +400,117 @@ func (f *SpecJSONFormatter) Format(result *runner.Result) error {
 	output.Summary.StatusCodeBreakdown.Count4xx = result.Count4xx()
 	output.Summary.StatusCodeBreakdown.Count5xx = result.Count5xx()
 	output.Summary.StatusCodeBreakdown.NetworkErrors = result.ErrorCount()
+	output.Summary.Latency = buildLatency(latency)
 
-	encoder := json.NewEncoder(f.writer)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	clusters, err := result.Clusters()
+	if err != nil {
+		return nil, err
+	}
+	output.Clusters = make([]SpecJSONCluster, 0, len(clusters))
+	for _, c := range clusters {
+		cluster := SpecJSONCluster{
+			Label:    c.Label,
+			Count:    c.Size(),
+			Status:   c.StatusCode,
+			Error:    c.Error,
+			BodyHash: c.BodyHash,
+			Indices:  c.Indices,
+		}
+		if c.Error == "" && !f.config.NoBody {
+			cluster.RepresentativeBody = c.Representative.Body
+		}
+		output.Clusters = append(output.Clusters, cluster)
+	}
+
+	if f.config.VerifyRequestID {
+		output.Summary.RequestIDEcho = buildEchoSummary(f.config.RequestIDHeader, result, sortedResponses)
+	}
+
+	if f.config.Diff {
+		report, err := verify.Diff(result)
+		if err != nil {
+			return nil, err
+		}
+		output.Consistency = &SpecJSONConsistency{
+			Reference:  report.Reference,
+			Consistent: report.Consistent(),
+			Identical:  report.Identical,
+			Deviations: make([]SpecJSONDeviation, 0, len(report.Deviations)),
+		}
+		for _, d := range report.Deviations {
+			output.Consistency.Deviations = append(output.Consistency.Deviations, SpecJSONDeviation{Index: d.Index, Diff: d.Diff})
+		}
+	}
+
+	if f.config.Idempotency {
+		report, err := verify.CheckIdempotency(result)
+		if err != nil {
+			return nil, err
+		}
+		output.Idempotency = &SpecJSONIdempotency{
+			Verdict:    report.Verdict,
+			Header:     report.Header,
+			Key:        report.Key,
+			Reference:  report.Reference,
+			Replays:    report.Replays,
+			Conflicts:  report.Conflicts,
+			Violations: make([]SpecJSONViolation, 0, len(report.Violations)),
+		}
+		for _, v := range report.Violations {
+			output.Idempotency.Violations = append(output.Idempotency.Violations, SpecJSONViolation{Index: v.Index, Reason: v.Reason})
+		}
+	}
+
+	return output, nil
+}
+
+func buildLatency(stats *runner.LatencyStats) *SpecJSONLatency {
+	if stats == nil {
+		return nil
+	}
+	latency := &SpecJSONLatency{
+		Count:     stats.Count,
+		MinMs:     milliseconds(stats.Min),
+		MaxMs:     milliseconds(stats.Max),
+		MeanMs:    milliseconds(stats.Mean),
+		StdDevMs:  milliseconds(stats.StdDev),
+		P50Ms:     milliseconds(stats.P50),
+		P90Ms:     milliseconds(stats.P90),
+		P95Ms:     milliseconds(stats.P95),
+		P99Ms:     milliseconds(stats.P99),
+		Outliers:  make([]int, 0, len(stats.Outliers)),
+		Histogram: make([]SpecJSONHistogramBucket, 0, len(stats.Histogram)),
+	}
+	latency.Outliers = append(latency.Outliers, stats.Outliers...)
+	for _, b := range stats.Histogram {
+		latency.Histogram = append(latency.Histogram, SpecJSONHistogramBucket{
+			LowerMs: milliseconds(b.Lower),
+			UpperMs: milliseconds(b.Upper),
+			Count:   b.Count,
+		})
+	}
+	return latency
+}
+
+func buildEchoSummary(header string, result *runner.Result, sortedResponses []*client.Response) *SpecJSONEchoSummary {
+	counts := result.EchoCounts()
+	summary := &SpecJSONEchoSummary{
+		Header:      header,
+		Matched:     counts[client.EchoMatched],
+		FoundInBody: counts[client.EchoInBody],
+		Missing:     counts[client.EchoMissing],
+		Mismatched:  counts[client.EchoMismatched],
+		Failures:    make([]SpecJSONEchoMismatch, 0),
+	}
+	for _, resp := range sortedResponses {
+		if resp.Echo != "" && !resp.Echo.OK() {
+			summary.Failures = append(summary.Failures, SpecJSONEchoMismatch{
+				Index:    resp.RequestIndex + 1,
+				Status:   string(resp.Echo),
+				Sent:     resp.RequestID,
+				Received: resp.ReceivedRequestID,
+			})
+		}
+	}
+	return summary
 }
@@ -0,0 +1,178 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestSpecJSONFormatterHeaders(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: time.Now(),
+		EndTime:   time.Now(),
+		Responses: []*client.Response{
+			{
+				RequestID:  "id-1",
+				StatusCode: 200,
+				Headers: http.Header{
+					"Set-Cookie": {"a=1", "b=2"},
+					"Vary":       {"Accept"},
+				},
+				Trailers: http.Header{"Grpc-Status": {"0"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, cfg).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var out SpecJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	resp := out.Results[0].Response
+	if got := resp.Headers["Set-Cookie"]; len(got) != 2 || got[0] != "a=1" || got[1] != "b=2" {
+		t.Errorf("Set-Cookie = %v, want [a=1 b=2]", got)
+	}
+	if got := resp.Trailers["Grpc-Status"]; len(got) != 1 || got[0] != "0" {
+		t.Errorf("Grpc-Status trailer = %v, want [0]", got)
+	}
+}
+
+func TestSpecJSONFormatterSentRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := config.NewConfig()
+	cfg.URL = server.URL
+	cfg.Method = http.MethodPost
+	cfg.RequestID = "id-1"
+	cfg.Headers = map[string]string{"X-Corr": "pre-" + config.RequestIDPlaceholder}
+	cfg.Body = `{"id":"` + config.RequestIDPlaceholder + `"}`
+
+	resp := client.NewClient(cfg).Do(context.Background(), 0)
+	if resp.Error != nil {
+		t.Fatalf("Do() error = %v", resp.Error)
+	}
+	result := &runner.Result{Config: cfg, StartTime: time.Now(), EndTime: time.Now(), Responses: []*client.Response{resp}}
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, cfg).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	var out SpecJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	// 設定値ではなく、プレースホルダーを展開して実際に送信した値を出力する
+	request := out.Results[0].Request
+	if got := request.Headers["X-Corr"]; got != "pre-id-1" {
+		t.Errorf("request.headers[X-Corr] = %q, want pre-id-1", got)
+	}
+	if got := request.Headers["X-Request-Id"]; got != "id-1" {
+		t.Errorf("request.headers[X-Request-Id] = %q, want id-1", got)
+	}
+	if got := request.Body; got != `{"id":"id-1"}` {
+		t.Errorf("request.body = %v, want {\"id\":\"id-1\"}", got)
+	}
+}
+
+func TestSpecJSONFormatterClusters(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Count = 3
+
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: time.Now(),
+		EndTime:   time.Now(),
+		Responses: []*client.Response{
+			{RequestIndex: 0, StatusCode: 201, Body: `{"id":1}`},
+			{RequestIndex: 1, StatusCode: 409, Body: `{"error":"conflict"}`},
+			{RequestIndex: 2, StatusCode: 201, Body: `{"id":1}`},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, cfg).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var out SpecJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(out.Clusters) != 2 {
+		t.Fatalf("len(clusters) = %d, want 2", len(out.Clusters))
+	}
+	first := out.Clusters[0]
+	if first.Label != "A" || first.Count != 2 || first.Status != 201 || first.RepresentativeBody != `{"id":1}` {
+		t.Errorf("clusters[0] = %+v", first)
+	}
+	if second := out.Clusters[1]; second.Status != 409 || second.Count != 1 || second.Indices[0] != 2 {
+		t.Errorf("clusters[1] = %+v", second)
+	}
+}
+
+func TestSpecJSONFormatterSelected(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Count = 3
+	cfg.Select = []string{"$.order.status", "$.order.version"}
+
+	result := &runner.Result{
+		Config:    cfg,
+		StartTime: time.Now(),
+		EndTime:   time.Now(),
+		Responses: []*client.Response{
+			{RequestIndex: 0, StatusCode: 200, Body: `{"order":{"status":"paid","version":3}}`},
+			{RequestIndex: 1, StatusCode: 200, Body: "not json"},
+			{RequestIndex: 2, Error: errors.New("connection refused")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, cfg).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var out struct {
+		Results []struct {
+			Selected map[string]any `json:"selected"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	want := map[string]any{"$.order.status": "paid", "$.order.version": float64(3)}
+	if got := out.Results[0].Selected; !reflect.DeepEqual(got, want) {
+		t.Errorf("results[0].selected = %v, want %v", got, want)
+	}
+	for _, i := range []int{1, 2} {
+		if got := out.Results[i].Selected; got != nil {
+			t.Errorf("results[%d].selected = %v, want omitted", i, got)
+		}
+	}
+}
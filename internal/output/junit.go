@@ -0,0 +1,311 @@
+package output
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/verify"
+)
+
+// JUnitFormatter formats results as JUnit XML for CI systems.
+//
+// Without verification options every request is a test case that fails on a
+// request error or a 4xx/5xx status. When assertions are enabled
+// (--verify-request-id, --diff, --idempotency) each assertion becomes a test
+// case instead, so that expected statuses such as 409 do not fail the build.
+type JUnitFormatter struct {
+	writer io.Writer
+	config *config.Config
+}
+
+// NewJUnitFormatter creates a new JUnit XML formatter.
+func NewJUnitFormatter(w io.Writer, cfg *config.Config) *JUnitFormatter {
+	return &JUnitFormatter{writer: w, config: cfg}
+}
+
+// JUnitTestSuites is the root element of a JUnit XML report.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups the test cases of one kind of check.
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []JUnitProperty `xml:"properties>property"`
+	TestCases  []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitProperty is a name/value pair attached to a test suite.
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestCase represents a single request or assertion.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut *JUnitOutput  `xml:"system-out,omitempty"`
+}
+
+// JUnitOutput is captured output attached to a test case.
+type JUnitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// JUnitFailure describes why a test case failed.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// Failure types used in JUnit reports.
+const (
+	junitFailureStatus  = "HTTPStatus"
+	junitFailureError   = "RequestError"
+	junitFailureTimeout = "Timeout"
+	junitFailureAssert  = "AssertionFailure"
+)
+
+// Format writes the result as JUnit XML.
+func (f *JUnitFormatter) Format(result *runner.Result) error {
+	var suites []JUnitTestSuite
+	var err error
+	if f.hasAssertions() {
+		suites, err = f.assertionSuites(result)
+		if err != nil {
+			return err
+		}
+	} else {
+		suites = []JUnitTestSuite{f.requestSuite(result)}
+	}
+
+	report := JUnitTestSuites{
+		Name: "conreq",
+		Time: seconds(result.EndTime.Sub(result.StartTime)),
+	}
+	properties := f.metadataProperties(result)
+	for i := range suites {
+		suite := &suites[i]
+		suite.Tests = len(suite.TestCases)
+		for _, tc := range suite.TestCases {
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+		}
+		suite.Time = report.Time
+		suite.Timestamp = result.StartTime.Format("2006-01-02T15:04:05")
+		suite.Properties = properties
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+	report.Suites = suites
+
+	if _, err := io.WriteString(f.writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(f.writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err = io.WriteString(f.writer, "\n")
+	return err
+}
+
+func (f *JUnitFormatter) hasAssertions() bool {
+	return f.config.VerifyRequestID || f.config.Diff || f.config.Idempotency
+}
+
+// requestSuite creates one test case per request.
+func (f *JUnitFormatter) requestSuite(result *runner.Result) JUnitTestSuite {
+	suite := JUnitTestSuite{Name: "conreq.requests"}
+	for _, resp := range sortByIndex(result.Responses) {
+		tc := f.requestCase(resp, suite.Name)
+		switch {
+		case resp.Error != nil:
+			tc.Failure = f.errorFailure(resp)
+		case resp.StatusCode >= 400:
+			tc.Failure = &JUnitFailure{
+				Message: fmt.Sprintf("unexpected status %d %s", resp.StatusCode, resp.StatusText),
+				Type:    junitFailureStatus,
+				Text:    f.failureDetail(resp),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	return suite
+}
+
+// assertionSuites creates one suite per enabled verification.
+func (f *JUnitFormatter) assertionSuites(result *runner.Result) ([]JUnitTestSuite, error) {
+	var suites []JUnitTestSuite
+	sorted := sortByIndex(result.Responses)
+
+	if f.config.VerifyRequestID {
+		suite := JUnitTestSuite{Name: "conreq.request_id_echo"}
+		for _, resp := range sorted {
+			tc := f.requestCase(resp, suite.Name)
+			if resp.Error != nil {
+				tc.Failure = f.errorFailure(resp)
+			} else if resp.Echo != "" && !resp.Echo.OK() {
+				// Echoが空のレスポンスはIDを送っていないため検証対象外
+				tc.Failure = &JUnitFailure{
+					Message: fmt.Sprintf("%s echo %s (sent: %s, received: %s)", f.config.RequestIDHeader, resp.Echo, resp.RequestID, resp.ReceivedRequestID),
+					Type:    junitFailureAssert,
+					Text:    f.failureDetail(resp),
+				}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		suites = append(suites, suite)
+	}
+
+	if f.config.Diff {
+		report, err := verify.Diff(result)
+		if err != nil {
+			return nil, err
+		}
+		deviations := make(map[int]string, len(report.Deviations))
+		for _, d := range report.Deviations {
+			deviations[d.Index] = d.Diff
+		}
+		suite := JUnitTestSuite{Name: "conreq.consistency"}
+		for _, resp := range sorted {
+			index := resp.RequestIndex + 1
+			if index == report.Reference {
+				continue
+			}
+			tc := f.requestCase(resp, suite.Name)
+			tc.Name = fmt.Sprintf("[%d] matches [%d]", index, report.Reference)
+			if diff, ok := deviations[index]; ok {
+				tc.Failure = &JUnitFailure{
+					Message: fmt.Sprintf("response [%d] differs from reference [%d]", index, report.Reference),
+					Type:    junitFailureAssert,
+					Text:    diff,
+				}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		suites = append(suites, suite)
+	}
+
+	if f.config.Idempotency {
+		report, err := verify.CheckIdempotency(result)
+		if err != nil {
+			return nil, err
+		}
+		tc := JUnitTestCase{
+			Name:      fmt.Sprintf("%s: %s", report.Header, report.Key),
+			ClassName: "conreq.idempotency",
+			Time:      seconds(result.EndTime.Sub(result.StartTime)),
+			SystemOut: &JUnitOutput{Text: fmt.Sprintf("Reference: [%d]\nReplays: %s\nConflicts: %s\n",
+				report.Reference, formatIndices(report.Replays), formatIndices(report.Conflicts))},
+		}
+		if !report.Passed() {
+			reasons := make([]string, 0, len(report.Violations))
+			for _, v := range report.Violations {
+				if v.Index > 0 {
+					reasons = append(reasons, fmt.Sprintf("[%d] %s", v.Index, v.Reason))
+				} else {
+					reasons = append(reasons, v.Reason)
+				}
+			}
+			tc.Failure = &JUnitFailure{
+				Message: fmt.Sprintf("idempotency verification failed: %d violation(s)", len(report.Violations)),
+				Type:    junitFailureAssert,
+				Text:    strings.Join(reasons, "\n"),
+			}
+		}
+		suites = append(suites, JUnitTestSuite{
+			Name:      "conreq.idempotency",
+			TestCases: []JUnitTestCase{tc},
+		})
+	}
+
+	return suites, nil
+}
+
+func (f *JUnitFormatter) requestCase(resp *client.Response, className string) JUnitTestCase {
+	return JUnitTestCase{
+		Name:      fmt.Sprintf("[%d] %s %s (%s: %s)", resp.RequestIndex+1, f.config.Method, f.config.URL, f.config.RequestIDHeader, resp.RequestID),
+		ClassName: className,
+		Time:      seconds(resp.Duration),
+	}
+}
+
+// errorFailure reports a request that did not receive a response.
+func (f *JUnitFormatter) errorFailure(resp *client.Response) *JUnitFailure {
+	failureType := junitFailureError
+	if isTimeoutError(resp.Error) {
+		failureType = junitFailureTimeout
+	}
+	return &JUnitFailure{
+		Message: resp.Error.Error(),
+		Type:    failureType,
+		Text:    f.failureDetail(resp),
+	}
+}
+
+// failureDetail describes the status, error and duration of a request.
+func (f *JUnitFormatter) failureDetail(resp *client.Response) string {
+	status := "ERROR"
+	if resp.Error == nil {
+		status = fmt.Sprintf("%d", resp.StatusCode)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Status: %s\n", status)
+	if resp.Error != nil {
+		fmt.Fprintf(&sb, "Error: %v\n", resp.Error)
+	}
+	fmt.Fprintf(&sb, "Duration: %dms\n", resp.Duration.Milliseconds())
+	fmt.Fprintf(&sb, "%s: %s\n", f.config.RequestIDHeader, resp.RequestID)
+	if resp.Error == nil && resp.Body != "" && !f.config.NoBody {
+		fmt.Fprintf(&sb, "\n%s\n", resp.Body)
+	}
+	return sb.String()
+}
+
+// metadataProperties describes the run, with the same values as the metadata
+// of the JSON output.
+func (f *JUnitFormatter) metadataProperties(result *runner.Result) []JUnitProperty {
+	return []JUnitProperty{
+		{Name: "url", Value: f.config.URL},
+		{Name: "method", Value: f.config.Method},
+		{Name: "concurrent", Value: fmt.Sprintf("%d", f.config.Count)},
+		{Name: "total_requests", Value: fmt.Sprintf("%d", len(result.Responses))},
+		{Name: "started_at", Value: result.StartTime.Format(time.RFC3339Nano)},
+		{Name: "completed_at", Value: result.EndTime.Format(time.RFC3339Nano)},
+		{Name: "total_duration_ms", Value: fmt.Sprintf("%d", result.EndTime.Sub(result.StartTime).Milliseconds())},
+	}
+}
+
+func isTimeoutError(err error) bool {
+	var timeoutErr *client.TimeoutError
+	return errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// histogramWidth is the length, in columns, of the longest histogram bar.
+const histogramWidth = 40
+
+// histogramBar is the character used to draw histogram bars.
+const histogramBar = '#'
+
+// formatLatency prints the response time percentiles, the standard deviation,
+// the outliers and a histogram of the response times. Nothing is printed for
+// fewer than two responses.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatLatency(stats *runner.LatencyStats) error {
+	// 1件だけでは分布にならないため表示しない
+	if stats == nil || stats.Count < 2 {
+		return nil
+	}
+
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.latency"))
+	fmt.Fprintf(f.writer, "Min: %s | Mean: %s | Max: %s | StdDev: %s\n",
+		formatDuration(stats.Min), formatDuration(stats.Mean), formatDuration(stats.Max), formatDuration(stats.StdDev))
+	fmt.Fprintf(f.writer, "p50: %s | p90: %s | p95: %s | p99: %s\n",
+		formatDuration(stats.P50), formatDuration(stats.P90), formatDuration(stats.P95), formatDuration(stats.P99))
+	if len(stats.Outliers) > 0 {
+		fmt.Fprintf(f.writer, "Outliers: %s\n", formatIndices(stats.Outliers))
+	} else {
+		fmt.Fprintln(f.writer, "Outliers: none")
+	}
+
+	peak := 0
+	for _, b := range stats.Histogram {
+		peak = max(peak, b.Count)
+	}
+
+	fmt.Fprintln(f.writer)
+	w := tabwriter.NewWriter(f.writer, 0, 0, 1, ' ', 0)
+	for _, b := range stats.Histogram {
+		// 件数が1以上のバケットは最低1文字描画する
+		length := b.Count * histogramWidth / peak
+		if b.Count > 0 {
+			length = max(length, 1)
+		}
+		bar := ""
+		if length > 0 {
+			bar = strings.Repeat(string(histogramBar), length) + " "
+		}
+		fmt.Fprintf(w, "%s\t- %s\t| %s%d\n", formatBucketBound(b.Lower), formatBucketBound(b.Upper), bar, b.Count)
+	}
+	return w.Flush()
+}
+
+// formatBucketBound formats a histogram bucket bound with one decimal place,
+// since buckets of fast requests are often narrower than a millisecond.
+func formatBucketBound(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func latencyResult() *runner.Result {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com"
+	cfg.Count = 5
+	cfg.NoBody = true
+
+	responses := make([]*client.Response, 0, 5)
+	for i, d := range []int{10, 20, 30, 40, 200} {
+		responses = append(responses, &client.Response{
+			RequestIndex: i,
+			RequestID:    "id",
+			StatusCode:   200,
+			Duration:     time.Duration(d) * time.Millisecond,
+		})
+	}
+	return &runner.Result{Config: cfg, StartTime: time.Now(), EndTime: time.Now(), Responses: responses}
+}
+
+func TestSpecTextFormatterLatency(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSpecTextFormatter(&buf).Format(latencyResult()); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	got := buf.String()
+
+	want := `=== Latency ===
+Min: 10ms | Mean: 60ms | Max: 200ms | StdDev: 70ms
+p50: 30ms | p90: 200ms | p95: 200ms | p99: 200ms
+Outliers: [5]
+
+10.0ms  - 57.5ms  | ######################################## 4
+57.5ms  - 105.0ms | 0
+105.0ms - 152.5ms | 0
+152.5ms - 200.0ms | ########## 1
+`
+	if !strings.Contains(got, want) {
+		t.Errorf("output does not contain\n%s\ngot:\n%s", want, got)
+	}
+	if !strings.Contains(got, "Time: 200ms | X-Request-ID: id | Outlier\n") {
+		t.Errorf("outlier request is not flagged:\n%s", got)
+	}
+	if strings.Count(got, "| Outlier") != 1 {
+		t.Errorf("want exactly one flagged request:\n%s", got)
+	}
+}
+
+func TestSpecTextFormatterLatencySingleResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []*client.Response
+	}{
+		{
+			name:      "single response",
+			responses: []*client.Response{{RequestIndex: 0, RequestID: "id", StatusCode: 200, Duration: 10 * time.Millisecond}},
+		},
+		{
+			name: "single successful response",
+			responses: []*client.Response{
+				{RequestIndex: 0, RequestID: "id", StatusCode: 200, Duration: 10 * time.Millisecond},
+				{RequestIndex: 1, RequestID: "id", Error: context.DeadlineExceeded, Duration: time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := latencyResult()
+			result.Responses = tt.responses
+
+			var buf bytes.Buffer
+			if err := NewSpecTextFormatter(&buf).Format(result); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); strings.Contains(got, "=== Latency ===") {
+				t.Errorf("latency section is shown for one response:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestSpecJSONFormatterLatencyNoResponses(t *testing.T) {
+	result := latencyResult()
+	result.Responses = []*client.Response{{RequestIndex: 0, RequestID: "id", Error: context.DeadlineExceeded, Duration: time.Second}}
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, result.Config).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var out SpecJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Summary.Latency != nil {
+		t.Errorf("summary.latency = %+v, want omitted", out.Summary.Latency)
+	}
+	if out.Results[0].Outlier {
+		t.Error("results[0].outlier = true, want false")
+	}
+}
+
+func TestSpecJSONFormatterLatency(t *testing.T) {
+	result := latencyResult()
+
+	var buf bytes.Buffer
+	if err := NewSpecJSONFormatter(&buf, result.Config).Format(result); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var out SpecJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	latency := out.Summary.Latency
+	if latency == nil {
+		t.Fatal("summary.latency is missing")
+	}
+	if latency.Count != 5 || latency.P50Ms != 30 || latency.P99Ms != 200 || latency.MeanMs != 60 || latency.StdDevMs != 70.71 {
+		t.Errorf("latency = %+v", latency)
+	}
+	if len(latency.Outliers) != 1 || latency.Outliers[0] != 5 {
+		t.Errorf("outliers = %v, want [5]", latency.Outliers)
+	}
+	if len(latency.Histogram) != 4 || latency.Histogram[0].LowerMs != 10 || latency.Histogram[0].Count != 4 {
+		t.Errorf("histogram = %+v", latency.Histogram)
+	}
+	for i, r := range out.Results {
+		if r.Outlier != (r.Index == 5) {
+			t.Errorf("results[%d].outlier = %v", i, r.Outlier)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"os"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// TestMain runs the tests with the English catalog, which the expected
+// error messages are written in. TestSpecTextFormatterWithoutLanguage covers
+// the output without a selected language.
+func TestMain(m *testing.M) {
+	if err := i18n.SetLanguage(i18n.English); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
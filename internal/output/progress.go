@@ -7,12 +7,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/shiroemons/conreq/internal/i18n"
 	"github.com/shiroemons/conreq/internal/runner"
 )
 
 // ProgressFormatter formats progress updates for streaming output.
 type ProgressFormatter struct {
 	writer       io.Writer
+	colors       palette
 	startTime    time.Time
 	totalCount   int
 	requestWidth int
@@ -31,10 +33,15 @@ func NewProgressFormatter(w io.Writer, totalCount int) *ProgressFormatter {
 	}
 }
 
+// SetColor enables or disables ANSI colours for status columns.
+func (f *ProgressFormatter) SetColor(enabled bool) {
+	f.colors = palette{enabled: enabled}
+}
+
 // Start prints the initial header.
 func (f *ProgressFormatter) Start() {
 	now := time.Now().Format("2006-01-02 15:04:05")
-	_, _ = fmt.Fprintf(f.writer, "🚀 Starting %d concurrent requests at %s\n\n", f.totalCount, now)
+	_, _ = fmt.Fprintf(f.writer, "%s\n\n", i18n.T("progress.start", f.totalCount, now))
 	f.printHeader()
 }
 
@@ -73,7 +80,18 @@ func (f *ProgressFormatter) FormatProgress(p *runner.Progress) {
 		}
 	}
 
-	_, _ = fmt.Fprintf(f.writer, "[%8s] %-18s | %-*s  %s  %-8s %4s  %s\n",
+	// 色付けはエスケープシーケンスで桁揃えが崩れないようパディング後に行う
+	statusText = fmt.Sprintf("%-8s", statusText)
+	httpCode = fmt.Sprintf("%4s", httpCode)
+	switch p.Status {
+	case "completed":
+		statusText = f.colors.status(p.StatusCode, statusText)
+		httpCode = f.colors.status(p.StatusCode, httpCode)
+	case "failed":
+		statusText = f.colors.error(statusText)
+	}
+
+	_, _ = fmt.Fprintf(f.writer, "[%8s] %-18s | %-*s  %s  %s %s  %s\n",
 		formatDuration(elapsed),
 		timeStr,
 		f.requestWidth, requestStr,
@@ -88,7 +106,7 @@ func (f *ProgressFormatter) FormatProgress(p *runner.Progress) {
 func (f *ProgressFormatter) Finish() {
 	elapsed := time.Since(f.startTime)
 	now := time.Now().Format("2006-01-02 15:04:05")
-	_, _ = fmt.Fprintf(f.writer, "\n🎉 All requests completed in %s at %s\n", formatDuration(elapsed), now)
+	_, _ = fmt.Fprintf(f.writer, "\n%s\n", i18n.T("progress.finished", formatDuration(elapsed), now))
 	_, _ = fmt.Fprintln(f.writer, strings.Repeat("=", 109))
 }
 
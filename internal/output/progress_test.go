@@ -134,3 +134,31 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestProgressFormatterColor(t *testing.T) {
+	progress := &runner.Progress{
+		Index:      0,
+		RequestID:  "test-id",
+		Status:     "completed",
+		StatusCode: 503,
+		StartTime:  time.Now(),
+	}
+
+	var plain, colored bytes.Buffer
+	NewProgressFormatter(&plain, 1).FormatProgress(progress)
+	f := NewProgressFormatter(&colored, 1)
+	f.SetColor(true)
+	f.FormatProgress(progress)
+
+	if strings.Contains(plain.String(), "\x1b[") {
+		t.Errorf("output without colour contains escape sequences: %q", plain.String())
+	}
+	if !strings.Contains(colored.String(), ansiRed+" 503"+ansiReset) {
+		t.Errorf("output = %q, want red status code", colored.String())
+	}
+	// エスケープシーケンスを除けば色なしの出力と同じ桁揃えになる
+	stripped := strings.NewReplacer(ansiRed, "", ansiReset, "").Replace(colored.String())
+	if plainLine, coloredLine := plain.String()[11:], stripped[11:]; plainLine != coloredLine {
+		t.Errorf("coloured row layout differs:\n%q\n%q", plainLine, coloredLine)
+	}
+}
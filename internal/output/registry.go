@@ -0,0 +1,171 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// Built-in output formats.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatHAR      = "har"
+	FormatJUnit    = "junit"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatHTML     = "html"
+	FormatTemplate = "template"
+)
+
+// Factory creates a Formatter that writes to w.
+type Factory func(w io.Writer, cfg *config.Config) (Formatter, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+func init() {
+	MustRegister(FormatText, func(w io.Writer, _ *config.Config) (Formatter, error) {
+		return NewSpecTextFormatter(w), nil
+	})
+	MustRegister(FormatJSON, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewSpecJSONFormatter(w, cfg), nil
+	})
+	MustRegister(FormatHAR, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewHARFormatter(w, cfg, cfg.Version), nil
+	})
+	MustRegister(FormatJUnit, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewJUnitFormatter(w, cfg), nil
+	})
+	MustRegister(FormatCSV, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewCSVFormatter(w, cfg, ',', cfg.Columns, !cfg.NoHeader), nil
+	})
+	MustRegister(FormatTSV, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewCSVFormatter(w, cfg, '\t', cfg.Columns, !cfg.NoHeader), nil
+	})
+	MustRegister(FormatHTML, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		return NewHTMLFormatter(w, cfg), nil
+	})
+	MustRegister(FormatTemplate, func(w io.Writer, cfg *config.Config) (Formatter, error) {
+		if cfg.Format == "" {
+			return nil, i18n.Errorf("output.template_required")
+		}
+		return NewTemplateFormatter(w, cfg, cfg.Format)
+	})
+}
+
+// Register makes an output format available under name.
+// Registering a name twice returns an error.
+func Register(name string, factory Factory) error {
+	if name == "" || strings.Contains(name, ":") {
+		return i18n.Errorf("output.invalid_format_name", name)
+	}
+	if factory == nil {
+		return i18n.Errorf("output.nil_factory", name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[name]; exists {
+		return i18n.Errorf("output.duplicate_format", name)
+	}
+	registry[name] = factory
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(name string, factory Factory) {
+	if err := Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// New returns a Formatter of the named format writing to w.
+func New(name string, w io.Writer, cfg *config.Config) (Formatter, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, i18n.Errorf("output.unsupported_format", name, strings.Join(Formats(), ", "))
+	}
+	return factory(w, cfg)
+}
+
+// Formats returns the registered format names in sorted order.
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateOutputs checks that every configured output can be created, so
+// that mistakes are reported before any request is sent.
+func ValidateOutputs(cfg *config.Config) error {
+	for _, o := range cfg.Outputs {
+		if _, err := New(o.Format, io.Discard, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dispatch writes the result to every configured output. Outputs to
+// config.Stdout are written to stdout, using stdoutFormatter instead of the
+// registered format when it is not nil; other outputs create their files.
+// All outputs are attempted and their errors are joined.
+func Dispatch(cfg *config.Config, result *runner.Result, stdout io.Writer, stdoutFormatter Formatter) error {
+	var errs []error
+	for _, o := range cfg.Outputs {
+		if err := dispatch(o, cfg, result, stdout, stdoutFormatter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func dispatch(o config.Output, cfg *config.Config, result *runner.Result, stdout io.Writer, stdoutFormatter Formatter) error {
+	if o.Path == config.Stdout {
+		if stdoutFormatter != nil {
+			return stdoutFormatter.Format(result)
+		}
+		formatter, err := New(o.Format, stdout, cfg)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(result)
+	}
+
+	file, err := os.Create(o.Path) //nolint:gosec // CLI argument
+	if err != nil {
+		return i18n.Errorf("output.create_file", err)
+	}
+	formatter, err := New(o.Format, file, cfg)
+	if err == nil {
+		err = formatter.Format(result)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = i18n.Errorf("output.write_file", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("%s (%s): %w", o.Path, o.Format, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/config"
+)
+
+func TestFormats(t *testing.T) {
+	want := []string{"csv", "har", "html", "json", "junit", "template", "text", "tsv"}
+	if got := Formats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Formats() = %v, want %v", got, want)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	factory := func(w io.Writer, _ *config.Config) (Formatter, error) {
+		return NewSpecTextFormatter(w), nil
+	}
+	tests := []struct {
+		name    string
+		format  string
+		factory Factory
+	}{
+		{"duplicate", FormatJSON, factory},
+		{"empty name", "", factory},
+		{"name with colon", "a:b", factory},
+		{"nil factory", "registry-test", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.format, tt.factory); err == nil {
+				t.Errorf("Register(%q) error = nil, want error", tt.format)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cfg := config.NewConfig()
+	if _, err := New("yaml", io.Discard, cfg); err == nil || !strings.Contains(err.Error(), "json") {
+		t.Errorf("New(yaml) error = %v, want an error listing the formats", err)
+	}
+	if _, err := New(FormatTemplate, io.Discard, cfg); err == nil {
+		t.Error("New(template) without --format error = nil, want error")
+	}
+
+	cfg.Format = "{{.URL"
+	cfg.Outputs = []config.Output{{Format: FormatTemplate, Path: config.Stdout}}
+	if err := ValidateOutputs(cfg); err == nil {
+		t.Error("ValidateOutputs() with an invalid template error = nil, want error")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	result := templateResult()
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "result.json")
+	junitPath := filepath.Join(dir, "report.xml")
+
+	cfg := result.Config
+	if err := cfg.ParseOutputs([]string{"text:-", "json:" + jsonPath, "junit:" + junitPath}); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := Dispatch(cfg, result, &stdout, nil); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "=== Summary ===") {
+		t.Errorf("stdout does not contain the text output:\n%s", stdout.String())
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc SpecJSONOutput
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("result.json is not valid JSON: %v", err)
+	}
+	if len(doc.Results) != len(result.Responses) {
+		t.Errorf("len(results) = %d, want %d", len(doc.Results), len(result.Responses))
+	}
+
+	data, err = os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal(data, new(struct{})); err != nil {
+		t.Errorf("report.xml is not valid XML: %v", err)
+	}
+}
+
+func TestDispatchStdoutFormatter(t *testing.T) {
+	result := templateResult()
+	cfg := result.Config
+	cfg.Outputs = []config.Output{{Format: FormatJSON, Path: config.Stdout}}
+
+	var stdout, events bytes.Buffer
+	if err := Dispatch(cfg, result, &stdout, NewSpecTextFormatter(&events)); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty when a stdout formatter is given", stdout.String())
+	}
+	if events.Len() == 0 {
+		t.Error("stdout formatter was not used")
+	}
+}
+
+func TestDispatchError(t *testing.T) {
+	result := templateResult()
+	dir := t.TempDir()
+	cfg := result.Config
+	cfg.Outputs = []config.Output{
+		{Format: FormatJSON, Path: filepath.Join(dir, "missing", "result.json")},
+		{Format: FormatJUnit, Path: filepath.Join(dir, "report.xml")},
+	}
+
+	err := Dispatch(cfg, result, io.Discard, nil)
+	if err == nil {
+		t.Fatal("Dispatch() error = nil, want error")
+	}
+	// 失敗した出力先があっても残りの出力先には書き込む
+	if _, statErr := os.Stat(filepath.Join(dir, "report.xml")); statErr != nil {
+		t.Errorf("report.xml was not written: %v", statErr)
+	}
+}
@@ -0,0 +1,19 @@
+package output
+
+import (
+	_ "embed"
+)
+
+// SchemaVersion is the version of the JSON output written to its
+// schema_version field. The minor version is incremented when fields are
+// added, and the major version when fields are removed, renamed or change in
+// type or meaning.
+const SchemaVersion = "1.0"
+
+//go:embed schema/output.schema.json
+var outputSchema []byte
+
+// Schema returns the JSON Schema (draft 2020-12) describing SpecJSONOutput.
+func Schema() []byte {
+	return outputSchema
+}
@@ -0,0 +1,225 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestSchemaVersionCompatibility fails when the fields of the JSON output
+// change without a new SchemaVersion. The shape of every released version is
+// recorded in testdata/schema/<version>.txt and must never be edited.
+func TestSchemaVersionCompatibility(t *testing.T) {
+	got := strings.Join(outputShape(reflect.TypeOf(SpecJSONOutput{}), ""), "\n") + "\n"
+
+	path := filepath.Join("testdata", "schema", SchemaVersion+".txt")
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("%s does not exist; record the shape of schema version %s:\n%s", path, SchemaVersion, got)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("the JSON output no longer matches schema version %s.\n"+
+			"Increment SchemaVersion, update schema/output.schema.json and record the new shape in testdata/schema/<version>.txt:\n%s",
+			SchemaVersion, got)
+	}
+}
+
+// TestSchemaMatchesOutput checks that the published JSON Schema describes
+// every field of SpecJSONOutput, with omitempty fields as optional.
+func TestSchemaMatchesOutput(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("Schema() is not valid JSON: %v", err)
+	}
+
+	version, _ := schemaProperty(schema, schema, "schema_version")
+	if version["const"] != SchemaVersion {
+		t.Errorf("schema_version const = %v, want %s", version["const"], SchemaVersion)
+	}
+	if id, _ := schema["$id"].(string); !strings.HasSuffix(id, "-"+SchemaVersion+".json") {
+		t.Errorf("$id = %q, want it to end with -%s.json", id, SchemaVersion)
+	}
+
+	compareSchema(t, schema, schema, reflect.TypeOf(SpecJSONOutput{}), "$")
+}
+
+func TestSpecJSONFormatterSchemaVersion(t *testing.T) {
+	output, err := NewSpecJSONFormatter(nil, templateResult().Config).Build(templateResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %q, want %q", output.SchemaVersion, SchemaVersion)
+	}
+}
+
+// outputShape lists every JSON field reachable from typ with its Go type.
+func outputShape(typ reflect.Type, prefix string) []string {
+	var lines []string
+	for _, field := range jsonFields(typ) {
+		name := prefix + field.name
+		if field.omitempty {
+			lines = append(lines, name+" "+typeName(field.typ)+" omitempty")
+		} else {
+			lines = append(lines, name+" "+typeName(field.typ))
+		}
+		if elem, suffix := structElem(field.typ); elem != nil {
+			lines = append(lines, outputShape(elem, name+suffix+".")...)
+		}
+	}
+	return lines
+}
+
+func typeName(typ reflect.Type) string {
+	if elem, suffix := structElem(typ); elem != nil {
+		return "object" + suffix
+	}
+	return typ.String()
+}
+
+// structElem returns the struct type of a struct, pointer or slice type and
+// the suffix that marks a slice element.
+func structElem(typ reflect.Type) (reflect.Type, string) {
+	suffix := ""
+	if typ.Kind() == reflect.Slice {
+		typ, suffix = typ.Elem(), "[]"
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, ""
+	}
+	return typ, suffix
+}
+
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+func jsonFields(typ reflect.Type) []jsonField {
+	fields := make([]jsonField, 0, typ.NumField())
+	for i := range typ.NumField() {
+		tag := typ.Field(i).Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" || name == "" {
+			continue
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			typ:       typ.Field(i).Type,
+			omitempty: slices.Contains(strings.Split(options, ","), "omitempty"),
+		})
+	}
+	return fields
+}
+
+func compareSchema(t *testing.T, root, node map[string]any, typ reflect.Type, path string) {
+	t.Helper()
+
+	node = resolveRef(root, node)
+	properties, _ := node["properties"].(map[string]any)
+	required := make(map[string]bool)
+	if list, ok := node["required"].([]any); ok {
+		for _, name := range list {
+			required[fmt.Sprint(name)] = true
+		}
+	}
+
+	fields := jsonFields(typ)
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		names[field.name] = true
+		fieldPath := path + "." + field.name
+
+		property, ok := schemaProperty(root, node, field.name)
+		if !ok {
+			t.Errorf("%s is missing from the schema", fieldPath)
+			continue
+		}
+		if required[field.name] == field.omitempty {
+			t.Errorf("%s: required = %v in the schema, want %v", fieldPath, required[field.name], !field.omitempty)
+		}
+		if want := jsonType(field.typ); want != "" {
+			if got := fmt.Sprint(property["type"]); got != want {
+				t.Errorf("%s: type = %s in the schema, want %s", fieldPath, got, want)
+			}
+		}
+
+		elem, suffix := structElem(field.typ)
+		if elem == nil {
+			continue
+		}
+		if suffix == "[]" {
+			items, _ := property["items"].(map[string]any)
+			compareSchema(t, root, items, elem, fieldPath+"[]")
+		} else {
+			compareSchema(t, root, structVariant(root, property), elem, fieldPath)
+		}
+	}
+
+	for name := range properties {
+		if !names[name] {
+			t.Errorf("%s.%s is in the schema but not in the output", path, name)
+		}
+	}
+}
+
+func schemaProperty(root, node map[string]any, name string) (map[string]any, bool) {
+	properties, _ := resolveRef(root, node)["properties"].(map[string]any)
+	property, ok := properties[name].(map[string]any)
+	return resolveRef(root, property), ok
+}
+
+// structVariant returns the object alternative of a nullable property.
+func structVariant(root, property map[string]any) map[string]any {
+	variants, ok := property["oneOf"].([]any)
+	if !ok {
+		return property
+	}
+	for _, v := range variants {
+		if variant := resolveRef(root, v.(map[string]any)); variant["type"] == "object" {
+			return variant
+		}
+	}
+	return property
+}
+
+func resolveRef(root, node map[string]any) map[string]any {
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+	defs, _ := root["$defs"].(map[string]any)
+	def, _ := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	return def
+}
+
+// jsonType returns the JSON Schema type of a Go type, or "" when the schema
+// is free to describe it differently (interfaces and nullable objects).
+func jsonType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64:
+		return "integer"
+	case reflect.Float64:
+		return "number"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Slice:
+		return "array"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,262 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// TemplateFormatter renders results through a user-defined text/template
+// (--format, --format-file). The template is executed with a TemplateData.
+type TemplateFormatter struct {
+	writer   io.Writer
+	config   *config.Config
+	template *template.Template
+}
+
+// TemplateData is the data model passed to --format templates.
+//
+// The field names and types of TemplateData, TemplateResult, TemplateSummary,
+// TemplateLatency and TemplateHistogramBucket are a stable contract: fields may be added, but existing
+// fields are not renamed, removed or changed in meaning.
+type TemplateData struct {
+	URL        string
+	Method     string
+	Concurrent int
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Duration   time.Duration // StartedAt から EndedAt まで
+	Results    []TemplateResult
+	Summary    TemplateSummary
+}
+
+// TemplateResult is a single request in TemplateData, in request order.
+type TemplateResult struct {
+	Index          int    // 1始まりのリクエスト番号
+	RequestID      string // 送信したRequest ID
+	CorrelationIDs map[string]string
+	TraceID        string
+	SpanID         string
+	StartedAt      time.Time
+	EndedAt        time.Time
+	Duration       time.Duration
+	Status         int    // レスポンスが無い場合は0
+	StatusText     string // "OK" など
+	Proto          string // "HTTP/1.1" など
+	Headers        http.Header
+	Trailers       http.Header
+	Body           string
+	BodySize       int
+	BodyHash       string // クラスタリングと同じボディのフィンガープリント
+	Error          string // レスポンスを受信できなかった場合のエラー
+	Outlier        bool   // 応答時間が外れ値か
+}
+
+// TemplateSummary aggregates the results.
+type TemplateSummary struct {
+	Total       int
+	Successful  int // 2xx
+	Failed      int // ネットワークエラー・タイムアウト
+	SuccessRate float64
+	Count2xx    int
+	Count3xx    int
+	Count4xx    int
+	Count5xx    int
+	// Latency is nil when no request received a response.
+	Latency *TemplateLatency
+}
+
+// TemplateLatency describes the distribution of response times.
+type TemplateLatency struct {
+	Count     int
+	Min       time.Duration
+	Max       time.Duration
+	Mean      time.Duration
+	StdDev    time.Duration // 母標準偏差
+	P50       time.Duration
+	P90       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+	Outliers  []int // 外れ値のリクエスト番号（1始まり）
+	Histogram []TemplateHistogramBucket
+}
+
+// TemplateHistogramBucket counts the durations in [Lower, Upper). The last
+// bucket also includes Upper.
+type TemplateHistogramBucket struct {
+	Lower time.Duration
+	Upper time.Duration
+	Count int
+}
+
+// TemplateFuncs are the helper functions available to --format templates.
+var TemplateFuncs = template.FuncMap{
+	// header returns the first value of the header name, or "".
+	"header": func(h http.Header, name string) string {
+		return h.Get(name)
+	},
+	// json selects a field from a JSON body with a JSONPath expression.
+	// Strings are returned as is and other values as compact JSON; "" is
+	// returned when the body is not JSON or nothing matched.
+	"json": templateJSON,
+	// duration formats a duration as 850µs, 123ms or 1.23s.
+	"duration": formatDuration,
+	// ms returns a duration in milliseconds with microsecond precision.
+	"ms": milliseconds,
+}
+
+// ParseTemplate compiles a --format template with TemplateFuncs.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, i18n.Errorf("output.template_parse", err)
+	}
+	return tmpl, nil
+}
+
+// NewTemplateFormatter creates a formatter for the template text.
+func NewTemplateFormatter(w io.Writer, cfg *config.Config, text string) (*TemplateFormatter, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{writer: w, config: cfg, template: tmpl}, nil
+}
+
+// Format renders the result through the template. A trailing newline is
+// added when the output does not end with one.
+func (f *TemplateFormatter) Format(result *runner.Result) error {
+	data, err := NewTemplateData(f.config, result)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := f.template.Execute(&buf, data); err != nil {
+		return i18n.Errorf("output.template_execute", err)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = f.writer.Write(buf.Bytes())
+	return err
+}
+
+// NewTemplateData converts a result into the template data model.
+func NewTemplateData(cfg *config.Config, result *runner.Result) (*TemplateData, error) {
+	ignore, err := jsonpath.ParseAll(cfg.IgnoreJSONPaths)
+	if err != nil {
+		return nil, err
+	}
+	latency := result.LatencyStats()
+
+	data := &TemplateData{
+		URL:        cfg.URL,
+		Method:     cfg.Method,
+		Concurrent: cfg.Count,
+		StartedAt:  result.StartTime,
+		EndedAt:    result.EndTime,
+		Duration:   result.EndTime.Sub(result.StartTime),
+		Results:    make([]TemplateResult, 0, len(result.Responses)),
+		Summary: TemplateSummary{
+			Total:      len(result.Responses),
+			Successful: result.SuccessCount(),
+			Failed:     result.ErrorCount(),
+			Count2xx:   result.Count2xx(),
+			Count3xx:   result.Count3xx(),
+			Count4xx:   result.Count4xx(),
+			Count5xx:   result.Count5xx(),
+			Latency:    newTemplateLatency(latency),
+		},
+	}
+	if data.Summary.Total > 0 {
+		data.Summary.SuccessRate = float64(data.Summary.Successful) / float64(data.Summary.Total) * 100
+	}
+
+	for _, resp := range sortByIndex(result.Responses) {
+		r := TemplateResult{
+			Index:          resp.RequestIndex + 1,
+			RequestID:      resp.RequestID,
+			CorrelationIDs: resp.CorrelationIDs,
+			TraceID:        resp.TraceID,
+			SpanID:         resp.SpanID,
+			StartedAt:      resp.Timestamp,
+			EndedAt:        resp.Timestamp.Add(resp.Duration),
+			Duration:       resp.Duration,
+			Headers:        resp.Headers,
+			Trailers:       resp.Trailers,
+		}
+		if resp.Error != nil {
+			r.Error = resp.Error.Error()
+		} else {
+			r.Status = resp.StatusCode
+			r.StatusText = resp.StatusText
+			r.Proto = resp.Proto
+			r.Body = resp.Body
+			r.BodySize = len(resp.Body)
+			r.BodyHash = result.ResponseBodyHash(resp, ignore)
+			r.Outlier = latency.IsOutlier(r.Index)
+		}
+		// 未受信でもテンプレートでヘッダーを参照できるようにする
+		if r.Headers == nil {
+			r.Headers = http.Header{}
+		}
+		data.Results = append(data.Results, r)
+	}
+	return data, nil
+}
+
+func newTemplateLatency(stats *runner.LatencyStats) *TemplateLatency {
+	if stats == nil {
+		return nil
+	}
+	latency := &TemplateLatency{
+		Count:     stats.Count,
+		Min:       stats.Min,
+		Max:       stats.Max,
+		Mean:      stats.Mean,
+		StdDev:    stats.StdDev,
+		P50:       stats.P50,
+		P90:       stats.P90,
+		P95:       stats.P95,
+		P99:       stats.P99,
+		Outliers:  stats.Outliers,
+		Histogram: make([]TemplateHistogramBucket, 0, len(stats.Histogram)),
+	}
+	for _, b := range stats.Histogram {
+		latency.Histogram = append(latency.Histogram, TemplateHistogramBucket{Lower: b.Lower, Upper: b.Upper, Count: b.Count})
+	}
+	return latency
+}
+
+func templateJSON(expr, body string) (string, error) {
+	path, err := jsonpath.Parse(expr)
+	if err != nil {
+		return "", err
+	}
+	selected, isJSON := jsonpath.Select(body, []*jsonpath.Path{path})
+	if !isJSON {
+		return "", nil
+	}
+	switch value := selected[path.String()].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(encoded)), nil
+	}
+}
@@ -0,0 +1,166 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func templateResult() *runner.Result {
+	cfg := config.NewConfig()
+	cfg.URL = "https://example.com/orders"
+	cfg.Method = "POST"
+	cfg.Count = 3
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &runner.Result{
+		Config:    cfg,
+		StartTime: start,
+		EndTime:   start.Add(250 * time.Millisecond),
+		Responses: []*client.Response{
+			{
+				RequestIndex: 1, RequestID: "id-2", StatusCode: 409, StatusText: "Conflict",
+				Headers:   http.Header{"X-Backend-Id": []string{"b"}},
+				Body:      `{"error":"conflict"}`,
+				Timestamp: start, Duration: 80 * time.Millisecond,
+			},
+			{
+				RequestIndex: 0, RequestID: "id-1", StatusCode: 201, StatusText: "Created",
+				Headers:   http.Header{"X-Backend-Id": []string{"a"}},
+				Body:      `{"order":{"status":"paid","version":3,"tags":["x"]}}`,
+				Timestamp: start, Duration: 1500 * time.Microsecond,
+			},
+			{RequestIndex: 2, RequestID: "id-3", Error: errors.New("connection refused"), Timestamp: start},
+		},
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "one line per request",
+			template: `{{range .Results}}{{println .Index .Status (duration .Duration) (header .Headers "X-Backend-Id")}}{{end}}`,
+			want:     "1 201 1ms a\n2 409 80ms b\n3 0 0µs \n",
+		},
+		{
+			name:     "json field selection",
+			template: `{{range .Results}}{{.Index}}:{{.Body | json "$.order.status"}}:{{.Body | json ".order.version"}}:{{json "$.order.tags" .Body}};{{end}}`,
+			want:     "1:paid:3:[\"x\"];2:::;3:::;\n",
+		},
+		{
+			name:     "errors and milliseconds",
+			template: `{{range .Results}}{{if .Error}}[{{.Index}}] {{.Error}}{{else}}[{{.Index}}] {{ms .Duration}}{{end}}{{"\n"}}{{end}}`,
+			want:     "[1] 1.5\n[2] 80\n[3] connection refused\n",
+		},
+		{
+			name:     "metadata and summary",
+			template: `{{.Method}} {{.URL}} x{{.Concurrent}} in {{duration .Duration}}: {{.Summary.Successful}}/{{.Summary.Total}} ok, {{.Summary.Count4xx}} 4xx, {{.Summary.Failed}} failed, p50 {{duration .Summary.Latency.P50}}`,
+			want:     "POST https://example.com/orders x3 in 250ms: 1/3 ok, 1 4xx, 1 failed, p50 1ms\n",
+		},
+		{
+			name:     "empty output",
+			template: `{{if false}}x{{end}}`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := templateResult()
+			var buf bytes.Buffer
+			f, err := NewTemplateFormatter(&buf, result.Config, tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateFormatter() error = %v", err)
+			}
+			if err := f.Format(result); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFormatterErrors(t *testing.T) {
+	if _, err := ParseTemplate(`{{range .Results}}`); err == nil || !strings.Contains(err.Error(), "failed to parse the template") {
+		t.Errorf("ParseTemplate() error = %v, want parse error", err)
+	}
+
+	result := templateResult()
+	tests := []string{
+		`{{.Unknown}}`,
+		`{{range .Results}}{{json "$.items[x]" .Body}}{{end}}`,
+	}
+	for _, text := range tests {
+		f, err := NewTemplateFormatter(&bytes.Buffer{}, result.Config, text)
+		if err != nil {
+			t.Fatalf("NewTemplateFormatter(%q) error = %v", text, err)
+		}
+		if err := f.Format(result); err == nil || !strings.Contains(err.Error(), "failed to execute the template") {
+			t.Errorf("Format(%q) error = %v, want execution error", text, err)
+		}
+	}
+}
+
+func TestNewTemplateData(t *testing.T) {
+	result := templateResult()
+	data, err := NewTemplateData(result.Config, result)
+	if err != nil {
+		t.Fatalf("NewTemplateData() error = %v", err)
+	}
+
+	if len(data.Results) != 3 {
+		t.Fatalf("len(Results) = %d, want 3", len(data.Results))
+	}
+	first, failed := data.Results[0], data.Results[2]
+	if first.Index != 1 || first.RequestID != "id-1" || first.BodySize != len(result.Responses[1].Body) || first.BodyHash == "" {
+		t.Errorf("Results[0] = %+v", first)
+	}
+	if !first.EndedAt.Equal(first.StartedAt.Add(first.Duration)) {
+		t.Errorf("Results[0].EndedAt = %v", first.EndedAt)
+	}
+	if failed.Status != 0 || failed.Error != "connection refused" || failed.Headers == nil {
+		t.Errorf("Results[2] = %+v", failed)
+	}
+	if data.Summary.SuccessRate < 33.3 || data.Summary.SuccessRate > 33.4 {
+		t.Errorf("Summary.SuccessRate = %v", data.Summary.SuccessRate)
+	}
+
+	stats := result.LatencyStats()
+	latency := data.Summary.Latency
+	if latency == nil {
+		t.Fatal("Summary.Latency = nil")
+	}
+	if latency.Count != 2 || latency.Min != stats.Min || latency.Max != stats.Max || latency.P50 != stats.P50 || latency.StdDev != stats.StdDev {
+		t.Errorf("Summary.Latency = %+v, want %+v", latency, stats)
+	}
+	if len(latency.Histogram) != len(stats.Histogram) {
+		t.Fatalf("len(Summary.Latency.Histogram) = %d, want %d", len(latency.Histogram), len(stats.Histogram))
+	}
+	for i, b := range stats.Histogram {
+		if got := latency.Histogram[i]; got.Lower != b.Lower || got.Upper != b.Upper || got.Count != b.Count {
+			t.Errorf("Summary.Latency.Histogram[%d] = %+v, want %+v", i, got, b)
+		}
+	}
+
+	result.Responses = result.Responses[2:]
+	data, err = NewTemplateData(result.Config, result)
+	if err != nil {
+		t.Fatalf("NewTemplateData() error = %v", err)
+	}
+	if data.Summary.Latency != nil {
+		t.Errorf("Summary.Latency without responses = %+v, want nil", data.Summary.Latency)
+	}
+}
@@ -0,0 +1,124 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/runner"
+	"github.com/shiroemons/conreq/internal/terminal"
+)
+
+// Timeline bar widths, in columns.
+const (
+	minTimelineWidth = 10
+	maxTimelineWidth = 200
+)
+
+// Characters used to draw the timeline.
+const (
+	timelineBar     = '='
+	timelineOverlap = '#'
+	timelineMarker  = '^'
+)
+
+// formatTimeline draws one bar per request on a common axis spanning the
+// run's wall-clock time. The window in which all requests were in flight at
+// once is drawn with '#' and marked with '^' below the bars.
+//
+//nolint:errcheck // io.Writer への出力エラーは無視
+func (f *SpecTextFormatter) formatTimeline(result *runner.Result) {
+	responses := sortByIndex(result.Responses)
+	if len(responses) == 0 {
+		return
+	}
+
+	origin := responses[0].Timestamp
+	end := origin
+	overlapStart, overlapEnd := origin, responses[0].Timestamp.Add(responses[0].Duration)
+	for _, resp := range responses {
+		start, finish := resp.Timestamp, resp.Timestamp.Add(resp.Duration)
+		if start.Before(origin) {
+			origin = start
+		}
+		if finish.After(end) {
+			end = finish
+		}
+		// 全リクエストが同時に実行中だった区間は「最後の開始」から「最初の完了」まで
+		if start.After(overlapStart) {
+			overlapStart = start
+		}
+		if finish.Before(overlapEnd) {
+			overlapEnd = finish
+		}
+	}
+	span := max(end.Sub(origin), time.Microsecond)
+	hasOverlap := len(responses) > 1 && overlapStart.Before(overlapEnd)
+
+	labels := make([]string, len(responses))
+	notes := make([]string, len(responses))
+	labelWidth, noteWidth := len("all"), 0
+	for i, resp := range responses {
+		labels[i] = fmt.Sprintf("[%d]", resp.RequestIndex+1)
+		status := "ERROR"
+		if resp.Error == nil {
+			status = fmt.Sprintf("%d", resp.StatusCode)
+		}
+		notes[i] = fmt.Sprintf("%s %dms", status, resp.Duration.Milliseconds())
+		labelWidth = max(labelWidth, len(labels[i]))
+		noteWidth = max(noteWidth, len(notes[i]))
+	}
+
+	// ラベル・枠線・注記を除いた幅をバーに使う
+	width := terminal.Width(f.writer) - labelWidth - noteWidth - 4
+	width = min(max(width, minTimelineWidth), maxTimelineWidth)
+	column := func(t time.Time) int {
+		c := int(float64(t.Sub(origin)) / float64(span) * float64(width))
+		return min(max(c, 0), width)
+	}
+	overlapFrom, overlapTo := column(overlapStart), max(column(overlapEnd), column(overlapStart)+1)
+
+	fmt.Fprintln(f.writer, "\n"+i18n.T("report.timeline"))
+	axisEnd := fmt.Sprintf("%dms", span.Milliseconds())
+	fmt.Fprintf(f.writer, "%*s  0ms%*s\n", labelWidth, "", width-3, axisEnd)
+
+	for i, resp := range responses {
+		line := []rune(strings.Repeat(" ", width))
+		from := column(resp.Timestamp)
+		to := min(max(column(resp.Timestamp.Add(resp.Duration)), from+1), width)
+		for c := from; c < to; c++ {
+			line[c] = timelineBar
+			if hasOverlap && c >= overlapFrom && c < overlapTo {
+				line[c] = timelineOverlap
+			}
+		}
+		fmt.Fprintf(f.writer, "%-*s |%s| %s\n", labelWidth, labels[i], string(line), notes[i])
+	}
+
+	if !hasOverlap {
+		if len(responses) > 1 {
+			fmt.Fprintln(f.writer, i18n.T("report.overlap_none"))
+		}
+		return
+	}
+
+	marker := []rune(strings.Repeat(" ", width))
+	for c := overlapFrom; c < min(overlapTo, width); c++ {
+		marker[c] = timelineMarker
+	}
+	fmt.Fprintf(f.writer, "%-*s |%s|\n", labelWidth, "all", string(marker))
+	fmt.Fprintln(f.writer, i18n.T("report.overlap_all",
+		len(responses),
+		formatOverlap(overlapEnd.Sub(overlapStart)),
+		overlapStart.Sub(origin).Milliseconds(),
+		overlapEnd.Sub(origin).Milliseconds(),
+	))
+}
+
+func formatOverlap(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
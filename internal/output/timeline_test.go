@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestFormatTimeline(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		columns   string
+		responses []*client.Response
+		want      []string
+	}{
+		{
+			name:    "overlapping requests",
+			columns: "40",
+			responses: []*client.Response{
+				{RequestIndex: 0, StatusCode: 200, Timestamp: start, Duration: 60 * time.Millisecond},
+				{RequestIndex: 1, StatusCode: 409, Timestamp: start.Add(20 * time.Millisecond), Duration: 80 * time.Millisecond},
+			},
+			// バー幅は 40 - ラベル3 - 注記8 - 枠線等4 = 25列（1列4ms）
+			want: []string{
+				"     0ms                 100ms",
+				"[1] |=====##########          | 200 60ms",
+				"[2] |     ##########==========| 409 80ms",
+				"all |     ^^^^^^^^^^          |",
+				"Overlap: all 2 requests in flight for 40ms (+20ms to +60ms)",
+			},
+		},
+		{
+			name:    "sequential requests",
+			columns: "40",
+			responses: []*client.Response{
+				{RequestIndex: 0, StatusCode: 200, Timestamp: start, Duration: 10 * time.Millisecond},
+				{RequestIndex: 1, StatusCode: 200, Timestamp: start.Add(50 * time.Millisecond), Duration: 10 * time.Millisecond},
+			},
+			want: []string{"Overlap: none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+
+			cfg := config.NewConfig()
+			var buf bytes.Buffer
+			f := NewSpecTextFormatter(&buf)
+			f.formatTimeline(&runner.Result{Config: cfg, Responses: tt.responses})
+
+			out := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q\n%s", want, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+// Package redact masks secrets such as credentials and session cookies before
+// results are written to any output.
+package redact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// DefaultHeaders are the headers redacted unless redaction is disabled.
+// Headers whose names look like API keys or tokens are redacted as well.
+var DefaultHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveNameParts mark header and query parameter names that carry
+// credentials, compared after removing '-' and '_' and lowercasing.
+var sensitiveNameParts = []string{"apikey", "token", "secret", "password", "signature"}
+
+// minSecretLength is the shortest value replaced inside free text such as
+// error messages; shorter values would match unrelated text.
+const minSecretLength = 4
+
+// Redactor replaces secret values with masks of the form [REDACTED:1a2b3c4d].
+//
+// The suffix is a keyed hash of the value with a key generated for each run,
+// so equal values get equal masks and comparisons between responses (diff,
+// clusters, idempotency) give the same verdict as on the original values,
+// while the mask cannot be used to recover or confirm the value.
+type Redactor struct {
+	disabled bool
+	headers  map[string]bool
+	paths    []*jsonpath.Path
+	key      []byte
+	secrets  []string // 自由記述のテキストから除去する値（長い順）
+	rawURL   string
+	url      string
+}
+
+// New creates a Redactor for the redaction rules of cfg. The header and query
+// parameter values in cfg are recorded so that they are also removed from
+// error messages.
+func New(cfg *config.Config) (*Redactor, error) {
+	paths, err := jsonpath.ParseAll(cfg.RedactJSONPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	r := &Redactor{
+		disabled: cfg.NoRedact,
+		headers:  make(map[string]bool),
+		paths:    paths,
+		key:      key,
+	}
+	for _, name := range append(append([]string(nil), DefaultHeaders...), cfg.RedactHeaders...) {
+		r.headers[http.CanonicalHeaderKey(name)] = true
+	}
+	if r.disabled {
+		return r, nil
+	}
+
+	secrets := make(map[string]bool)
+	addSecret := func(value string) {
+		secrets[value] = true
+		// "Bearer <token>" の形式ではトークン部分単体も除去する
+		if _, token, ok := strings.Cut(value, " "); ok {
+			secrets[token] = true
+		}
+	}
+	for name, value := range cfg.Headers {
+		if r.IsSensitiveHeader(name) {
+			addSecret(value)
+		}
+	}
+	for _, h := range cfg.CorrelationHeaders {
+		if r.IsSensitiveHeader(h.Name) {
+			addSecret(h.Value)
+		}
+	}
+	var urlSecrets []string
+	r.rawURL = cfg.URL
+	r.url, urlSecrets = r.redactURL(cfg.URL)
+	for _, secret := range urlSecrets {
+		addSecret(secret)
+	}
+
+	// 長い値から置換して、部分一致による取りこぼしを防ぐ
+	for secret := range secrets {
+		if len(secret) >= minSecretLength {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+	sort.Slice(r.secrets, func(i, j int) bool {
+		if len(r.secrets[i]) != len(r.secrets[j]) {
+			return len(r.secrets[i]) > len(r.secrets[j])
+		}
+		return r.secrets[i] < r.secrets[j]
+	})
+	return r, nil
+}
+
+// IsSensitiveHeader reports whether the values of the header are redacted.
+func (r *Redactor) IsSensitiveHeader(name string) bool {
+	if r.disabled {
+		return false
+	}
+	return r.headers[http.CanonicalHeaderKey(name)] || isSensitiveName(name)
+}
+
+func isSensitiveName(name string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask returns the mask that replaces value.
+func (r *Redactor) Mask(value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(value))
+	return "[REDACTED:" + hex.EncodeToString(mac.Sum(nil))[:8] + "]"
+}
+
+// Header returns the value of the header, masked when it is sensitive. The
+// values of other headers have the recorded secrets removed, as in Text.
+func (r *Redactor) Header(name, value string) string {
+	if !r.IsSensitiveHeader(name) {
+		return r.Text(value)
+	}
+	return r.Mask(value)
+}
+
+// Headers returns a copy of h with the values of sensitive headers masked.
+func (r *Redactor) Headers(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	redacted := make(http.Header, len(h))
+	for name, values := range h {
+		masked := make([]string, len(values))
+		for i, value := range values {
+			masked[i] = r.Header(name, value)
+		}
+		redacted[name] = masked
+	}
+	return redacted
+}
+
+// Body returns body with the values matched by the --redact-json-path rules
+// masked. Bodies that are not JSON or contain no matches are returned as is.
+func (r *Redactor) Body(body string) string {
+	if r.disabled || len(r.paths) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return body
+	}
+
+	matched := false
+	for _, p := range r.paths {
+		doc = p.Update(doc, func(old any) (any, bool) {
+			matched = true
+			if s, ok := old.(string); ok {
+				return r.Mask(s), true
+			}
+			encoded, _ := json.Marshal(old)
+			return r.Mask(string(encoded)), true
+		})
+	}
+	if !matched {
+		return body
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return body
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// URL returns rawURL with the password and the values of sensitive query
+// parameters masked.
+func (r *Redactor) URL(rawURL string) string {
+	if r.disabled {
+		return rawURL
+	}
+	if rawURL == r.rawURL {
+		return r.url
+	}
+	redacted, _ := r.redactURL(rawURL)
+	return redacted
+}
+
+// redactURL masks rawURL and returns the values it masked.
+func (r *Redactor) redactURL(rawURL string) (string, []string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL, nil
+	}
+
+	redacted := rawURL
+	var secrets []string
+	if password, ok := u.User.Password(); ok {
+		secrets = append(secrets, password)
+		redacted = strings.Replace(redacted, ":"+password+"@", ":"+r.Mask(password)+"@", 1)
+	}
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			name, value, ok := strings.Cut(param, "=")
+			if !ok || value == "" || !isSensitiveName(name) && !r.headers[http.CanonicalHeaderKey(name)] {
+				continue
+			}
+			secrets = append(secrets, value)
+			params[i] = name + "=" + r.Mask(value)
+		}
+		redacted = strings.Replace(redacted, u.RawQuery, strings.Join(params, "&"), 1)
+	}
+	return redacted, secrets
+}
+
+// Text removes the recorded secret values from free text such as error
+// messages and bodies that echo the request.
+func (r *Redactor) Text(s string) string {
+	if r.disabled {
+		return s
+	}
+	if r.rawURL != "" && r.rawURL != r.url {
+		s = strings.ReplaceAll(s, r.rawURL, r.url)
+	}
+	for _, secret := range r.secrets {
+		if strings.Contains(s, secret) {
+			s = strings.ReplaceAll(s, secret, r.Mask(secret))
+		}
+	}
+	return s
+}
+
+// Error returns err with its message redacted. The original error stays
+// available through errors.As and errors.Is.
+func (r *Redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := r.Text(err.Error())
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string { return e.message }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// Config returns a copy of cfg with the request secrets masked, for use by
+// the output formatters.
+func (r *Redactor) Config(cfg *config.Config) *config.Config {
+	if r.disabled {
+		return cfg
+	}
+	redacted := *cfg
+	redacted.URL = r.URL(cfg.URL)
+	redacted.Body = r.Text(r.Body(cfg.Body))
+	if cfg.Headers != nil {
+		redacted.Headers = make(map[string]string, len(cfg.Headers))
+		for name, value := range cfg.Headers {
+			redacted.Headers[name] = r.Header(name, value)
+		}
+	}
+	redacted.CorrelationHeaders = make([]config.CorrelationHeader, len(cfg.CorrelationHeaders))
+	for i, h := range cfg.CorrelationHeaders {
+		if h.Value != "" {
+			h.Value = r.Header(h.Name, h.Value)
+		}
+		redacted.CorrelationHeaders[i] = h
+	}
+	redacted.CorrelationValues = r.values(cfg.CorrelationValues)
+	return &redacted
+}
+
+// Result returns a copy of result whose config and responses are redacted.
+// The fingerprints of redacted bodies are recorded from the received bodies,
+// because the masks change from run to run.
+func (r *Redactor) Result(result *runner.Result) *runner.Result {
+	if r.disabled {
+		return result
+	}
+	redacted := *result
+	redacted.Config = r.Config(result.Config)
+	redacted.Responses = make([]*client.Response, len(result.Responses))
+	redacted.BodyHashes = maps.Clone(result.BodyHashes)
+	// 無効なJSONPathは出力時にエラーになるため、ここでは記録を省略する
+	ignore, ignoreErr := jsonpath.ParseAll(result.Config.IgnoreJSONPaths)
+	for i, resp := range result.Responses {
+		redacted.Responses[i] = r.Response(resp)
+		if ignoreErr != nil || resp.Error != nil || redacted.Responses[i].Body == resp.Body {
+			continue
+		}
+		if redacted.BodyHashes == nil {
+			redacted.BodyHashes = make(map[int]string)
+		}
+		redacted.BodyHashes[resp.RequestIndex] = result.ResponseBodyHash(resp, ignore)
+	}
+	return &redacted
+}
+
+// Response returns a copy of resp with its headers, bodies and error redacted.
+// Recorded secrets echoed in the bodies or in other headers are removed too.
+func (r *Redactor) Response(resp *client.Response) *client.Response {
+	if r.disabled {
+		return resp
+	}
+	redacted := *resp
+	redacted.CorrelationIDs = r.values(resp.CorrelationIDs)
+	redacted.RequestHeaders = r.Headers(resp.RequestHeaders)
+	redacted.RequestBody = r.Text(r.Body(resp.RequestBody))
+	redacted.Headers = r.Headers(resp.Headers)
+	redacted.Trailers = r.Headers(resp.Trailers)
+	redacted.Body = r.Text(r.Body(resp.Body))
+	redacted.Error = r.Error(resp.Error)
+	return &redacted
+}
+
+// Progress returns a channel that forwards the progress events of in with
+// their errors redacted. The channel is closed when in is closed.
+func (r *Redactor) Progress(in <-chan *runner.Progress) <-chan *runner.Progress {
+	if r.disabled {
+		return in
+	}
+	out := make(chan *runner.Progress, cap(in))
+	go func() {
+		defer close(out)
+		for p := range in {
+			redacted := *p
+			redacted.Error = r.Error(p.Error)
+			out <- &redacted
+		}
+	}()
+	return out
+}
+
+// values masks the values of header-like maps keyed by header name.
+func (r *Redactor) values(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(m))
+	for name, value := range m {
+		redacted[name] = r.Header(name, value)
+	}
+	return redacted
+}
@@ -0,0 +1,119 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+)
+
+// bodyHashLength is the number of hex characters kept from the body digest.
+const bodyHashLength = 12
+
+// Cluster is a group of equivalent responses: the same status code and the
+// same body fingerprint, or the same error for failed requests.
+type Cluster struct {
+	Label      string // "A", "B", ... in descending order of size
+	StatusCode int    // 0 for failed requests
+	Error      string // error message shared by failed requests
+	BodyHash   string // shortened SHA-256 of the normalised body
+	Indices    []int  // 1-based request indices in ascending order
+	// Representative is the response with the lowest index in the cluster.
+	Representative *client.Response
+}
+
+// Size returns the number of responses in the cluster.
+func (c *Cluster) Size() int {
+	return len(c.Indices)
+}
+
+// Clusters groups the responses into equivalence classes by status code and
+// body fingerprint. JSON bodies are normalised and the configured
+// IgnoreJSONPaths are removed before hashing, so that volatile fields such as
+// timestamps do not split otherwise identical responses.
+// Clusters are ordered by size, largest first, then by their lowest index.
+func (r *Result) Clusters() ([]*Cluster, error) {
+	var ignore []*jsonpath.Path
+	if r.Config != nil {
+		var err error
+		ignore, err = jsonpath.ParseAll(r.Config.IgnoreJSONPaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*client.Response, len(r.Responses))
+	copy(sorted, r.Responses)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].RequestIndex < sorted[j].RequestIndex
+	})
+
+	type clusterKey struct {
+		status int
+		err    string
+		hash   string
+	}
+	byKey := make(map[clusterKey]*Cluster)
+	var clusters []*Cluster
+
+	for _, resp := range sorted {
+		var key clusterKey
+		if resp.Error != nil {
+			key.err = resp.Error.Error()
+		} else {
+			key.status = resp.StatusCode
+			key.hash = r.ResponseBodyHash(resp, ignore)
+		}
+
+		c, ok := byKey[key]
+		if !ok {
+			c = &Cluster{
+				StatusCode:     key.status,
+				Error:          key.err,
+				BodyHash:       key.hash,
+				Representative: resp,
+			}
+			byKey[key] = c
+			clusters = append(clusters, c)
+		}
+		c.Indices = append(c.Indices, resp.RequestIndex+1)
+	}
+
+	// 出現順を保ったまま件数の多い順に並べる
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Size() > clusters[j].Size()
+	})
+	for i, c := range clusters {
+		c.Label = clusterLabel(i)
+	}
+	return clusters, nil
+}
+
+// BodyHash returns the shortened SHA-256 fingerprint of body after
+// normalisation with jsonpath.NormalizeBody.
+func BodyHash(body string, ignore []*jsonpath.Path) string {
+	normalized, _ := jsonpath.NormalizeBody(body, ignore)
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])[:bodyHashLength]
+}
+
+// ResponseBodyHash returns the fingerprint of the body of resp: the one
+// recorded in BodyHashes, or BodyHash of the body. ignore must be the
+// IgnoreJSONPaths of the result's config.
+func (r *Result) ResponseBodyHash(resp *client.Response, ignore []*jsonpath.Path) string {
+	if hash, ok := r.BodyHashes[resp.RequestIndex]; ok {
+		return hash
+	}
+	return BodyHash(resp.Body, ignore)
+}
+
+// clusterLabel returns spreadsheet-style labels: A..Z, AA, AB, ...
+func clusterLabel(i int) string {
+	label := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		label = string(rune('A'+(i-1)%26)) + label
+	}
+	return label
+}
@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+)
+
+func TestResultClusters(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.IgnoreJSONPaths = []string{"$.created_at"}
+
+	result := &Result{
+		Config: cfg,
+		Responses: []*client.Response{
+			{RequestIndex: 4, StatusCode: 409, Body: `{"error":"conflict"}`},
+			{RequestIndex: 0, StatusCode: 201, Body: `{"id":1,"created_at":"10:00"}`},
+			{RequestIndex: 2, StatusCode: 201, Body: `{"created_at":"10:01", "id":1}`},
+			{RequestIndex: 1, StatusCode: 409, Body: `{"error":"conflict"}`},
+			{RequestIndex: 3, StatusCode: 201, Body: `{"id":1}`},
+			{RequestIndex: 5, Error: context.DeadlineExceeded},
+			{RequestIndex: 6, StatusCode: 201, Body: `{"id":2}`},
+		},
+	}
+
+	clusters, err := result.Clusters()
+	if err != nil {
+		t.Fatalf("Clusters() error = %v", err)
+	}
+
+	tests := []struct {
+		label   string
+		status  int
+		err     string
+		indices []int
+		rep     int
+	}{
+		{label: "A", status: 201, indices: []int{1, 3, 4}, rep: 0},
+		{label: "B", status: 409, indices: []int{2, 5}, rep: 1},
+		{label: "C", err: context.DeadlineExceeded.Error(), indices: []int{6}, rep: 5},
+		{label: "D", status: 201, indices: []int{7}, rep: 6},
+	}
+
+	if len(clusters) != len(tests) {
+		t.Fatalf("len(Clusters()) = %d, want %d", len(clusters), len(tests))
+	}
+	for i, tt := range tests {
+		c := clusters[i]
+		if c.Label != tt.label || c.StatusCode != tt.status || c.Error != tt.err {
+			t.Errorf("cluster %d = {%s %d %q}, want {%s %d %q}", i, c.Label, c.StatusCode, c.Error, tt.label, tt.status, tt.err)
+		}
+		if !reflect.DeepEqual(c.Indices, tt.indices) {
+			t.Errorf("cluster %s Indices = %v, want %v", c.Label, c.Indices, tt.indices)
+		}
+		if c.Representative.RequestIndex != tt.rep {
+			t.Errorf("cluster %s Representative = %d, want %d", c.Label, c.Representative.RequestIndex, tt.rep)
+		}
+		if (c.Error == "") == (c.BodyHash == "") {
+			t.Errorf("cluster %s BodyHash = %q, Error = %q", c.Label, c.BodyHash, c.Error)
+		}
+	}
+
+	if clusters[0].BodyHash == clusters[3].BodyHash {
+		t.Error("clusters with different bodies have the same hash")
+	}
+}
+
+func TestClusterLabel(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := clusterLabel(tt.index); got != tt.want {
+			t.Errorf("clusterLabel(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
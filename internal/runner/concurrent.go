@@ -8,6 +8,7 @@ import (
 
 	"github.com/shiroemons/conreq/internal/client"
 	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/tracing"
 	"github.com/shiroemons/conreq/pkg/requestid"
 )
 
@@ -20,6 +21,9 @@ type Progress struct {
 	Error      error
 	StartTime  time.Time
 	EndTime    time.Time
+	// BytesReceived reports the response body bytes read so far. It is set
+	// from the "running" event on and may be called while the request runs.
+	BytesReceived func() int64
 }
 
 // Result represents the result of concurrent HTTP requests.
@@ -28,6 +32,10 @@ type Result struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Config    *config.Config
+	// BodyHashes records the body fingerprints by request index for bodies
+	// changed after the run, such as by redaction, so that the fingerprints
+	// still describe the received bodies. See ResponseBodyHash.
+	BodyHashes map[int]string
 }
 
 // Runner executes concurrent HTTP requests.
@@ -51,6 +59,29 @@ func (r *Runner) ProgressChannel() <-chan *Progress {
 	return r.progressChan
 }
 
+// TeeProgress returns n channels that each receive every progress event of in.
+// The channels are closed when in is closed. Each event is delivered to the
+// channels in order, so a slow reader delays the others.
+func TeeProgress(in <-chan *Progress, n int) []<-chan *Progress {
+	outs := make([]chan *Progress, n)
+	readers := make([]<-chan *Progress, n)
+	for i := range outs {
+		outs[i] = make(chan *Progress, cap(in))
+		readers[i] = outs[i]
+	}
+	go func() {
+		for p := range in {
+			for _, out := range outs {
+				out <- p
+			}
+		}
+		for _, out := range outs {
+			close(out)
+		}
+	}()
+	return readers
+}
+
 // Run executes concurrent HTTP requests.
 func (r *Runner) Run(ctx context.Context) (*Result, error) {
 	result := &Result{
@@ -59,13 +90,30 @@ func (r *Runner) Run(ctx context.Context) (*Result, error) {
 		Config:    r.config,
 	}
 
+	// 実行ごとに生成器を作成する（連番形式のカウンターは実行単位）
+	generator, err := requestid.New(r.config.RequestIDFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	correlations, err := newCorrelations(r.config.CorrelationHeaders)
+	if err != nil {
+		return nil, err
+	}
+
 	responseChan := make(chan *client.Response, r.config.Count)
 	var wg sync.WaitGroup
 
 	// 同一RequestIDモードの場合、事前に生成
 	var sharedRequestID string
 	if r.config.SameRequestID && r.config.RequestID == "" {
-		sharedRequestID = requestid.Generate()
+		sharedRequestID = generator.Generate()
+	}
+
+	// トレースIDも同一RequestIDモードに合わせて共有する（スパンIDは常にリクエストごと）
+	var sharedTraceID string
+	if r.config.SameRequestID && r.config.TracePropagation != "" {
+		sharedTraceID = tracing.NewTraceID()
 	}
 
 	for i := 0; i < r.config.Count; i++ {
@@ -84,10 +132,20 @@ func (r *Runner) Run(ctx context.Context) (*Result, error) {
 			} else {
 				// 個別RequestIDモード
 				if cfg.RequestID == "" {
-					cfg.RequestID = requestid.Generate()
+					cfg.RequestID = generator.Generate()
 				}
 			}
 
+			cfg.CorrelationValues = correlationValues(correlations)
+
+			if cfg.TracePropagation != "" {
+				cfg.TraceID = sharedTraceID
+				if cfg.TraceID == "" {
+					cfg.TraceID = tracing.NewTraceID()
+				}
+				cfg.SpanID = tracing.NewSpanID()
+			}
+
 			// Send pending status
 			r.progressChan <- &Progress{
 				Index:     index,
@@ -106,10 +164,11 @@ func (r *Runner) Run(ctx context.Context) (*Result, error) {
 			// Send running status
 			startTime := time.Now()
 			r.progressChan <- &Progress{
-				Index:     index,
-				RequestID: cfg.RequestID,
-				Status:    "running",
-				StartTime: startTime,
+				Index:         index,
+				RequestID:     cfg.RequestID,
+				Status:        "running",
+				StartTime:     startTime,
+				BytesReceived: client.BytesReceived,
 			}
 
 			response := client.Do(ctx, index)
@@ -121,13 +180,14 @@ func (r *Runner) Run(ctx context.Context) (*Result, error) {
 				status = "failed"
 			}
 			r.progressChan <- &Progress{
-				Index:      index,
-				RequestID:  cfg.RequestID,
-				Status:     status,
-				StatusCode: response.StatusCode,
-				Error:      response.Error,
-				StartTime:  startTime,
-				EndTime:    endTime,
+				Index:         index,
+				RequestID:     cfg.RequestID,
+				Status:        status,
+				StatusCode:    response.StatusCode,
+				Error:         response.Error,
+				StartTime:     startTime,
+				EndTime:       endTime,
+				BytesReceived: client.BytesReceived,
 			}
 
 			responseChan <- response
@@ -245,3 +305,26 @@ func (r *Result) Count5xx() int {
 	}
 	return count
 }
+
+// EchoCounts returns the number of responses for each request ID echo outcome.
+// It is empty unless echo verification was enabled.
+func (r *Result) EchoCounts() map[client.EchoStatus]int {
+	counts := make(map[client.EchoStatus]int)
+	for _, resp := range r.Responses {
+		if resp.Echo != "" {
+			counts[resp.Echo]++
+		}
+	}
+	return counts
+}
+
+// EchoFailureCount returns the number of responses whose request ID echo was missing or mismatched.
+func (r *Result) EchoFailureCount() int {
+	count := 0
+	for _, resp := range r.Responses {
+		if resp.Echo != "" && !resp.Echo.OK() {
+			count++
+		}
+	}
+	return count
+}
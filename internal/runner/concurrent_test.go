@@ -2,10 +2,14 @@ package runner
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
 )
 
 func TestResultMethods(t *testing.T) {
@@ -129,4 +133,150 @@ func TestResultMethods(t *testing.T) {
 			t.Errorf("Count5xx() = %d, want 2", got)
 		}
 	})
+
+	t.Run("Echo counts", func(t *testing.T) {
+		result := &Result{
+			Responses: []*client.Response{
+				{Echo: client.EchoMatched},
+				{Echo: client.EchoMatched},
+				{Echo: client.EchoInBody},
+				{Echo: client.EchoMissing},
+				{Echo: client.EchoMismatched},
+				{Error: context.DeadlineExceeded},
+			},
+		}
+
+		counts := result.EchoCounts()
+		if counts[client.EchoMatched] != 2 || counts[client.EchoInBody] != 1 {
+			t.Errorf("EchoCounts() = %v", counts)
+		}
+		if got := result.EchoFailureCount(); got != 2 {
+			t.Errorf("EchoFailureCount() = %d, want 2", got)
+		}
+	})
+}
+
+func TestRunTraceContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		sameRequestID bool
+		wantTraceIDs  int
+	}{
+		{"different trace per request", false, 3},
+		{"shared trace with same request ID", true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			received := make(map[string]bool)
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				received[r.Header.Get("traceparent")] = true
+				mu.Unlock()
+			}))
+			defer server.Close()
+
+			cfg := config.NewConfig()
+			cfg.URL = server.URL
+			cfg.Count = 3
+			cfg.SameRequestID = tt.sameRequestID
+			cfg.TracePropagation = "w3c"
+
+			r := NewRunner(cfg)
+			go func() {
+				for range r.ProgressChannel() {
+				}
+			}()
+			result, err := r.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			traceIDs := make(map[string]bool)
+			spanIDs := make(map[string]bool)
+			for _, resp := range result.Responses {
+				traceIDs[resp.TraceID] = true
+				spanIDs[resp.SpanID] = true
+				want := "00-" + resp.TraceID + "-" + resp.SpanID + "-01"
+				if !received[want] {
+					t.Errorf("server did not receive traceparent %q", want)
+				}
+			}
+			if len(traceIDs) != tt.wantTraceIDs {
+				t.Errorf("distinct trace IDs = %d, want %d", len(traceIDs), tt.wantTraceIDs)
+			}
+			if len(spanIDs) != cfg.Count {
+				t.Errorf("distinct span IDs = %d, want %d", len(spanIDs), cfg.Count)
+			}
+		})
+	}
+}
+
+func TestRunCorrelationHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	cfg := config.NewConfig()
+	cfg.URL = server.URL
+	cfg.Count = 3
+	cfg.CorrelationHeaders = []config.CorrelationHeader{
+		{Name: "Idempotency-Key", Shared: true},
+		{Name: "X-Seq", Format: "sequential:s"},
+		{Name: "X-Tenant", Value: "acme"},
+	}
+
+	result, err := NewRunner(cfg).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	keys := make(map[string]bool)
+	seqs := make(map[string]bool)
+	for _, resp := range result.Responses {
+		keys[resp.CorrelationIDs["Idempotency-Key"]] = true
+		seqs[resp.CorrelationIDs["X-Seq"]] = true
+		if got := resp.CorrelationIDs["X-Tenant"]; got != "acme" {
+			t.Errorf("X-Tenant = %q, want acme", got)
+		}
+	}
+	if len(keys) != 1 {
+		t.Errorf("shared header produced %d distinct values, want 1", len(keys))
+	}
+	for _, want := range []string{"s-000001", "s-000002", "s-000003"} {
+		if !seqs[want] {
+			t.Errorf("per-request header missing %q: %v", want, seqs)
+		}
+	}
+}
+
+func TestTeeProgress(t *testing.T) {
+	in := make(chan *Progress, 3)
+	for i := range 3 {
+		in <- &Progress{Index: i, Status: "completed"}
+	}
+	close(in)
+
+	outs := TeeProgress(in, 2)
+	var wg sync.WaitGroup
+	counts := make([]int, len(outs))
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range out {
+				if p.Index != counts[i] {
+					t.Errorf("reader %d got index %d, want %d", i, p.Index, counts[i])
+				}
+				counts[i]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, n := range counts {
+		if n != 3 {
+			t.Errorf("reader %d received %d events, want 3", i, n)
+		}
+	}
 }
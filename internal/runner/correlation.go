@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/pkg/requestid"
+)
+
+// correlation generates the values of one additional correlation header.
+type correlation struct {
+	header    config.CorrelationHeader
+	generator requestid.Generator
+	shared    string
+}
+
+// newCorrelations prepares generators for the configured correlation headers.
+// Shared values are generated once so that every request sends the same value.
+func newCorrelations(headers []config.CorrelationHeader) ([]*correlation, error) {
+	correlations := make([]*correlation, 0, len(headers))
+	for _, h := range headers {
+		generator, err := requestid.New(h.Format)
+		if err != nil {
+			return nil, err
+		}
+		c := &correlation{header: h, generator: generator}
+		if h.Value == "" && h.Shared {
+			c.shared = generator.Generate()
+		}
+		correlations = append(correlations, c)
+	}
+	return correlations, nil
+}
+
+// correlationValues returns the header values for a single request.
+func correlationValues(correlations []*correlation) map[string]string {
+	if len(correlations) == 0 {
+		return nil
+	}
+
+	values := make(map[string]string, len(correlations))
+	for _, c := range correlations {
+		switch {
+		case c.header.Value != "":
+			values[c.header.Name] = c.header.Value
+		case c.header.Shared:
+			values[c.header.Name] = c.shared
+		default:
+			values[c.header.Name] = c.generator.Generate()
+		}
+	}
+	return values
+}
@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// maxHistogramBuckets caps the number of histogram buckets.
+const maxHistogramBuckets = 10
+
+// minOutlierSamples is the number of durations needed before outliers are
+// flagged; with fewer samples the quartiles are not meaningful.
+const minOutlierSamples = 4
+
+// LatencyStats describes the distribution of response times.
+type LatencyStats struct {
+	Count  int
+	Min    time.Duration
+	Max    time.Duration
+	Mean   time.Duration
+	StdDev time.Duration // population standard deviation
+	P50    time.Duration
+	P90    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+	// Outliers are the 1-based indices of requests outside the Tukey fences
+	// (more than 1.5 IQR below the first or above the third quartile).
+	Outliers  []int
+	Histogram []HistogramBucket
+}
+
+// HistogramBucket counts the durations in [Lower, Upper). The last bucket
+// also includes Upper.
+type HistogramBucket struct {
+	Lower time.Duration
+	Upper time.Duration
+	Count int
+}
+
+// IsOutlier reports whether the request with the 1-based index is an outlier.
+// It is safe to call on nil stats.
+func (s *LatencyStats) IsOutlier(index int) bool {
+	if s == nil {
+		return false
+	}
+	for _, i := range s.Outliers {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// LatencyStats computes response time statistics over the requests that
+// received a response, whatever their status code. It returns nil when no
+// request received a response.
+func (r *Result) LatencyStats() *LatencyStats {
+	var durations []time.Duration
+	for _, resp := range r.Responses {
+		if resp.Error == nil {
+			durations = append(durations, resp.Duration)
+		}
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats := &LatencyStats{
+		Count: len(durations),
+		Min:   durations[0],
+		Max:   durations[len(durations)-1],
+		P50:   percentile(durations, 50),
+		P90:   percentile(durations, 90),
+		P95:   percentile(durations, 95),
+		P99:   percentile(durations, 99),
+	}
+
+	var sum float64
+	for _, d := range durations {
+		sum += float64(d)
+	}
+	mean := sum / float64(len(durations))
+	var variance float64
+	for _, d := range durations {
+		variance += (float64(d) - mean) * (float64(d) - mean)
+	}
+	stats.Mean = time.Duration(mean)
+	stats.StdDev = time.Duration(math.Sqrt(variance / float64(len(durations))))
+
+	if len(durations) >= minOutlierSamples {
+		q1, q3 := percentile(durations, 25), percentile(durations, 75)
+		fence := time.Duration(1.5 * float64(q3-q1))
+		for _, resp := range r.Responses {
+			if resp.Error == nil && (resp.Duration < q1-fence || resp.Duration > q3+fence) {
+				stats.Outliers = append(stats.Outliers, resp.RequestIndex+1)
+			}
+		}
+		sort.Ints(stats.Outliers)
+	}
+
+	stats.Histogram = histogram(durations)
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted durations using the
+// nearest-rank method, so the result is always an observed duration.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// histogram splits the range of sorted durations into equal-width buckets.
+// The number of buckets follows Sturges' rule, capped at maxHistogramBuckets.
+func histogram(sorted []time.Duration) []HistogramBucket {
+	lowest, highest := sorted[0], sorted[len(sorted)-1]
+	if lowest == highest {
+		return []HistogramBucket{{Lower: lowest, Upper: highest, Count: len(sorted)}}
+	}
+
+	count := min(int(math.Ceil(math.Log2(float64(len(sorted)))))+1, maxHistogramBuckets)
+	width := (highest - lowest + time.Duration(count) - 1) / time.Duration(count)
+	buckets := make([]HistogramBucket, count)
+	for i := range buckets {
+		buckets[i].Lower = lowest + time.Duration(i)*width
+		buckets[i].Upper = buckets[i].Lower + width
+	}
+	buckets[count-1].Upper = highest
+
+	for _, d := range sorted {
+		i := min(int((d-lowest)/width), count-1)
+		buckets[i].Count++
+	}
+	return buckets
+}
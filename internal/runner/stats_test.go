@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+)
+
+func ms(n int) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+func TestResultLatencyStats(t *testing.T) {
+	result := &Result{Responses: []*client.Response{
+		{RequestIndex: 0, StatusCode: 200, Duration: ms(10)},
+		{RequestIndex: 1, StatusCode: 200, Duration: ms(20)},
+		{RequestIndex: 2, StatusCode: 409, Duration: ms(30)},
+		{RequestIndex: 3, StatusCode: 200, Duration: ms(40)},
+		{RequestIndex: 4, StatusCode: 200, Duration: ms(200)},
+		{RequestIndex: 5, Error: errors.New("timeout"), Duration: ms(5000)},
+	}}
+
+	stats := result.LatencyStats()
+	if stats == nil {
+		t.Fatal("LatencyStats() = nil")
+	}
+
+	durations := []struct {
+		name      string
+		got, want time.Duration
+	}{
+		{"Min", stats.Min, ms(10)},
+		{"Max", stats.Max, ms(200)},
+		{"Mean", stats.Mean, ms(60)},
+		{"StdDev", stats.StdDev, 70710678 * time.Nanosecond}, // sqrt(5000ms²)
+		{"P50", stats.P50, ms(30)},
+		{"P90", stats.P90, ms(200)},
+		{"P95", stats.P95, ms(200)},
+		{"P99", stats.P99, ms(200)},
+	}
+	for _, d := range durations {
+		if d.got != d.want {
+			t.Errorf("%s = %v, want %v", d.name, d.got, d.want)
+		}
+	}
+
+	if stats.Count != 5 {
+		t.Errorf("Count = %d, want 5", stats.Count)
+	}
+	if !reflect.DeepEqual(stats.Outliers, []int{5}) || !stats.IsOutlier(5) || stats.IsOutlier(1) {
+		t.Errorf("Outliers = %v, want [5]", stats.Outliers)
+	}
+
+	// Sturges: ceil(log2 5) + 1 = 4 buckets of 47.5ms
+	wantCounts := []int{4, 0, 0, 1}
+	if len(stats.Histogram) != len(wantCounts) {
+		t.Fatalf("len(Histogram) = %d, want %d", len(stats.Histogram), len(wantCounts))
+	}
+	for i, b := range stats.Histogram {
+		if b.Count != wantCounts[i] {
+			t.Errorf("Histogram[%d] = %+v, want count %d", i, b, wantCounts[i])
+		}
+	}
+	if first, last := stats.Histogram[0], stats.Histogram[len(stats.Histogram)-1]; first.Lower != ms(10) || last.Upper != ms(200) {
+		t.Errorf("Histogram range = %v..%v, want 10ms..200ms", first.Lower, last.Upper)
+	}
+}
+
+func TestResultLatencyStatsEdgeCases(t *testing.T) {
+	stats := (&Result{Responses: []*client.Response{{Error: errors.New("refused")}}}).LatencyStats()
+	if stats != nil {
+		t.Errorf("LatencyStats() without responses = %+v, want nil", stats)
+	}
+	if stats.IsOutlier(1) {
+		t.Error("IsOutlier() on nil stats = true, want false")
+	}
+
+	result := &Result{Responses: []*client.Response{
+		{RequestIndex: 0, StatusCode: 200, Duration: ms(15)},
+		{RequestIndex: 1, StatusCode: 200, Duration: ms(15)},
+	}}
+	stats = result.LatencyStats()
+	if stats.StdDev != 0 || stats.P99 != ms(15) || len(stats.Outliers) != 0 {
+		t.Errorf("LatencyStats() = %+v", stats)
+	}
+	if want := []HistogramBucket{{Lower: ms(15), Upper: ms(15), Count: 2}}; !reflect.DeepEqual(stats.Histogram, want) {
+		t.Errorf("Histogram = %+v, want %+v", stats.Histogram, want)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{ms(1), ms(2), ms(3), ms(4), ms(5), ms(6), ms(7), ms(8), ms(9), ms(10)}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, ms(1)},
+		{25, ms(3)},
+		{50, ms(5)},
+		{90, ms(9)},
+		{99, ms(10)},
+		{100, ms(10)},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package terminal
+
+import (
+	"io"
+	"os"
+)
+
+// Colour modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ColorModes lists the valid colour modes.
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// IsValidColorMode reports whether mode is a valid colour mode.
+// The empty string is treated as ColorAuto.
+func IsValidColorMode(mode string) bool {
+	switch mode {
+	case "", ColorAuto, ColorAlways, ColorNever:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether w writes to a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled decides whether output written to w should be coloured.
+// In auto mode colour is used only for terminals, and never when the
+// NO_COLOR environment variable is set (https://no-color.org/).
+func ColorEnabled(mode string, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return IsTerminal(w)
+}
@@ -0,0 +1,10 @@
+//go:build darwin
+
+package terminal
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
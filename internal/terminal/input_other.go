@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package terminal
+
+import (
+	"os"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// RawInput is not supported on this platform.
+func RawInput(_ *os.File) (restore func() error, err error) {
+	return nil, i18n.Errorf("terminal.unsupported_input")
+}
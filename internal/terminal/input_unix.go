@@ -0,0 +1,42 @@
+//go:build linux || darwin
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/shiroemons/conreq/internal/i18n"
+)
+
+// RawInput switches the terminal attached to f to unbuffered input without
+// echo, so that single key presses can be read. Signal keys such as Ctrl-C
+// are delivered as bytes instead of signals. The returned function restores
+// the previous mode.
+func RawInput(f *os.File) (restore func() error, err error) {
+	var old syscall.Termios
+	if err := termios(f, ioctlGetTermios, &old); err != nil {
+		return nil, i18n.Errorf("terminal.get_attr", err)
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(f, ioctlSetTermios, &raw); err != nil {
+		return nil, i18n.Errorf("terminal.set_attr", err)
+	}
+
+	return func() error {
+		return termios(f, ioctlSetTermios, &old)
+	}, nil
+}
+
+func termios(f *os.File, request uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(unsafe.Pointer(t))) //nolint:gosec // the ioctl reads or writes t
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
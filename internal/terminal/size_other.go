@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package terminal
+
+import "os"
+
+// fileSize is not supported on this platform; Width and Height fall back to
+// the environment variables.
+func fileSize(_ *os.File) (width, height int) {
+	return 0, 0
+}
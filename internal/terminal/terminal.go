@@ -0,0 +1,41 @@
+// Package terminal detects the capabilities of the terminal an output stream writes to.
+package terminal
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// DefaultWidth is used when the terminal width cannot be determined.
+const DefaultWidth = 80
+
+// Width returns the width in columns of the terminal w writes to.
+// The COLUMNS environment variable takes precedence, and DefaultWidth is
+// returned when w is not a terminal.
+func Width(w io.Writer) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if f, ok := w.(*os.File); ok {
+		if n, _ := fileSize(f); n > 0 {
+			return n
+		}
+	}
+	return DefaultWidth
+}
+
+// Height returns the height in rows of the terminal w writes to.
+// The LINES environment variable takes precedence, and 0 is returned when
+// the height is unknown.
+func Height(w io.Writer) int {
+	if n, err := strconv.Atoi(os.Getenv("LINES")); err == nil && n > 0 {
+		return n
+	}
+	if f, ok := w.(*os.File); ok {
+		if _, n := fileSize(f); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
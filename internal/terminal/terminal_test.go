@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns string
+		want    int
+	}{
+		{name: "COLUMNS", columns: "132", want: 132},
+		{name: "invalid COLUMNS", columns: "wide", want: DefaultWidth},
+		{name: "not a terminal", columns: "", want: DefaultWidth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+			if got := Width(&bytes.Buffer{}); got != tt.want {
+				t.Errorf("Width() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines string
+		want  int
+	}{
+		{name: "LINES", lines: "50", want: 50},
+		{name: "invalid LINES", lines: "tall", want: 0},
+		{name: "not a terminal", lines: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LINES", tt.lines)
+			if got := Height(&bytes.Buffer{}); got != tt.want {
+				t.Errorf("Height() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		noColor string
+		want    bool
+	}{
+		{name: "always", mode: ColorAlways, want: true},
+		{name: "always ignores NO_COLOR", mode: ColorAlways, noColor: "1", want: true},
+		{name: "never", mode: ColorNever, want: false},
+		{name: "auto without terminal", mode: ColorAuto, want: false},
+		{name: "empty is auto", mode: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if got := ColorEnabled(tt.mode, &bytes.Buffer{}); got != tt.want {
+				t.Errorf("ColorEnabled(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidColorMode(t *testing.T) {
+	for _, mode := range append(ColorModes, "") {
+		if !IsValidColorMode(mode) {
+			t.Errorf("IsValidColorMode(%q) = false, want true", mode)
+		}
+	}
+	if IsValidColorMode("yes") {
+		t.Error("IsValidColorMode(\"yes\") = true, want false")
+	}
+}
+
+func TestRawInputNotTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if restore, err := RawInput(f); err == nil {
+		_ = restore()
+		t.Error("RawInput() on a regular file succeeded, want error")
+	}
+}
@@ -0,0 +1,81 @@
+// Package tracing generates distributed tracing identifiers and propagation headers.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// Supported propagation formats.
+const (
+	FormatNone    = ""
+	FormatW3C     = "w3c"
+	FormatB3      = "b3"
+	FormatB3Multi = "b3multi"
+)
+
+// Formats lists the propagation formats accepted by IsValidFormat.
+var Formats = []string{FormatW3C, FormatB3, FormatB3Multi}
+
+// IsValidFormat reports whether format is a supported propagation format.
+// The empty string (propagation disabled) is valid.
+func IsValidFormat(format string) bool {
+	if format == FormatNone {
+		return true
+	}
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTraceID returns a random 16-byte trace ID as 32 lowercase hex characters.
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// NewSpanID returns a random 8-byte span ID as 16 lowercase hex characters.
+func NewSpanID() string {
+	return randomHex(8)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	for {
+		_, _ = rand.Read(buf)
+		// 全ゼロのIDは仕様上無効
+		for _, b := range buf {
+			if b != 0 {
+				return hex.EncodeToString(buf)
+			}
+		}
+	}
+}
+
+// Headers returns the propagation headers for the given format.
+// Requests are always marked as sampled so that they show up in trace viewers.
+// traceState is only used by the W3C format and is omitted when empty.
+func Headers(format, traceID, spanID, traceState string) map[string]string {
+	headers := make(map[string]string)
+	if traceID == "" || spanID == "" {
+		return headers
+	}
+
+	switch format {
+	case FormatW3C:
+		headers["traceparent"] = fmt.Sprintf("00-%s-%s-01", traceID, spanID)
+		if traceState != "" {
+			headers["tracestate"] = traceState
+		}
+	case FormatB3:
+		headers["b3"] = fmt.Sprintf("%s-%s-1", traceID, spanID)
+	case FormatB3Multi:
+		headers["X-B3-TraceId"] = traceID
+		headers["X-B3-SpanId"] = spanID
+		headers["X-B3-Sampled"] = "1"
+	}
+	return headers
+}
@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewIDs(t *testing.T) {
+	traceID := NewTraceID()
+	if !regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(traceID) {
+		t.Errorf("NewTraceID() = %q, want 32 hex characters", traceID)
+	}
+	spanID := NewSpanID()
+	if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(spanID) {
+		t.Errorf("NewSpanID() = %q, want 16 hex characters", spanID)
+	}
+	if NewTraceID() == traceID {
+		t.Error("NewTraceID() returned the same ID twice")
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name       string
+		format     string
+		traceState string
+		want       map[string]string
+	}{
+		{
+			name:   "w3c",
+			format: FormatW3C,
+			want:   map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		},
+		{
+			name:       "w3c with tracestate",
+			format:     FormatW3C,
+			traceState: "conreq=1",
+			want: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"tracestate":  "conreq=1",
+			},
+		},
+		{
+			name:   "b3 single",
+			format: FormatB3,
+			want:   map[string]string{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		},
+		{
+			name:   "b3 multi",
+			format: FormatB3Multi,
+			want: map[string]string{
+				"X-B3-TraceId": traceID,
+				"X-B3-SpanId":  spanID,
+				"X-B3-Sampled": "1",
+			},
+		},
+		{
+			name:   "disabled",
+			format: FormatNone,
+			want:   map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Headers(tt.format, traceID, spanID, tt.traceState)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Headers() = %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("Headers()[%q] = %q, want %q", key, got[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestIsValidFormat(t *testing.T) {
+	for _, format := range []string{"", "w3c", "b3", "b3multi"} {
+		if !IsValidFormat(format) {
+			t.Errorf("IsValidFormat(%q) = false, want true", format)
+		}
+	}
+	if IsValidFormat("jaeger") {
+		t.Error("IsValidFormat(\"jaeger\") = true, want false")
+	}
+}
@@ -0,0 +1,126 @@
+package verify
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// defaultIgnoredHeaders are response headers that differ on every response.
+var defaultIgnoredHeaders = []string{"Date"}
+
+// Deviation is a response that differs from the reference response.
+type Deviation struct {
+	Index int
+	Diff  string
+}
+
+// DiffReport is the outcome of comparing every response with a reference response.
+type DiffReport struct {
+	Reference  int
+	Identical  []int
+	Deviations []Deviation
+}
+
+// Consistent reports whether every response matched the reference.
+func (r *DiffReport) Consistent() bool {
+	return len(r.Deviations) == 0
+}
+
+// Diff compares each response with the reference response (Config.DiffReference,
+// 1-based) on the status, the compared headers and the JSON-normalised body.
+// Headers listed in Config.DiffHeaders are compared, or all headers when it is
+// empty; Config.IgnoreHeaders, Date and Config.IgnoreJSONPaths are excluded.
+func Diff(result *runner.Result) (*DiffReport, error) {
+	cfg := result.Config
+	ignore, err := jsonpath.ParseAll(cfg.IgnoreJSONPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*client.Response, len(result.Responses))
+	copy(responses, result.Responses)
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].RequestIndex < responses[j].RequestIndex
+	})
+
+	report := &DiffReport{Identical: make([]int, 0), Deviations: make([]Deviation, 0)}
+	if len(responses) == 0 {
+		return report, nil
+	}
+
+	reference := responses[0]
+	for _, resp := range responses {
+		if resp.RequestIndex+1 == cfg.DiffReference {
+			reference = resp
+		}
+	}
+	report.Reference = reference.RequestIndex + 1
+
+	ignoredHeaders := make(map[string]bool)
+	for _, name := range append(append([]string(nil), defaultIgnoredHeaders...), cfg.IgnoreHeaders...) {
+		ignoredHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+
+	referenceDoc := comparisonDocument(reference, cfg.DiffHeaders, ignoredHeaders, ignore)
+	for _, resp := range responses {
+		if resp == reference {
+			continue
+		}
+		doc := comparisonDocument(resp, cfg.DiffHeaders, ignoredHeaders, ignore)
+		diff := UnifiedDiff(fmt.Sprintf("[%d]", report.Reference), fmt.Sprintf("[%d]", resp.RequestIndex+1), referenceDoc, doc)
+		if diff == "" {
+			report.Identical = append(report.Identical, resp.RequestIndex+1)
+		} else {
+			report.Deviations = append(report.Deviations, Deviation{Index: resp.RequestIndex + 1, Diff: diff})
+		}
+	}
+
+	return report, nil
+}
+
+// comparisonDocument renders the compared parts of a response as text lines.
+func comparisonDocument(resp *client.Response, selected []string, ignoredHeaders map[string]bool, ignore []*jsonpath.Path) string {
+	var sb strings.Builder
+	if resp.Error != nil {
+		fmt.Fprintf(&sb, "error: %v\n", resp.Error)
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "status: %d\n", resp.StatusCode)
+
+	names := selected
+	if len(names) == 0 {
+		for name := range resp.Headers {
+			names = append(names, name)
+		}
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		key := http.CanonicalHeaderKey(name)
+		if !ignoredHeaders[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values := resp.Headers.Values(key)
+		if len(values) == 0 {
+			fmt.Fprintf(&sb, "header %s: (none)\n", key)
+		}
+		for _, value := range values {
+			fmt.Fprintf(&sb, "header %s: %s\n", key, value)
+		}
+	}
+
+	body, _ := jsonpath.NormalizeBody(resp.Body, ignore)
+	sb.WriteString("body:\n")
+	sb.WriteString(body)
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package verify
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	b := "a\nb\nc\nD\ne\nf\ng\nh\ni\nj\nk\n"
+
+	want := `--- old
++++ new
+@@ -1,10 +1,11 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+ h
+ i
+ j
++k
+`
+	if got := UnifiedDiff("old", "new", a, b); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := UnifiedDiff("old", "new", a, a); got != "" {
+		t.Errorf("UnifiedDiff() of equal input = %q, want empty", got)
+	}
+}
+
+func TestUnifiedDiffSeparateHunks(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[1] = "X"
+	b[18] = "Y"
+
+	got := UnifiedDiff("old", "new", strings.Join(a, "\n"), strings.Join(b, "\n"))
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("UnifiedDiff() produced %d hunks, want 2\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@") || !strings.Contains(got, "@@ -16,5 +16,5 @@") {
+		t.Errorf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Count = 4
+	cfg.Diff = true
+	cfg.IgnoreJSONPaths = []string{"$.served_at"}
+	cfg.IgnoreHeaders = []string{"X-Request-ID"}
+
+	header := func(kv ...string) http.Header {
+		h := http.Header{"Date": {"changes every time"}}
+		for i := 0; i < len(kv); i += 2 {
+			h.Add(kv[i], kv[i+1])
+		}
+		return h
+	}
+
+	result := &runner.Result{
+		Config: cfg,
+		Responses: []*client.Response{
+			{RequestIndex: 2, StatusCode: 409, Headers: header("Content-Type", "application/json"), Body: `{"error":"conflict"}`},
+			{RequestIndex: 0, StatusCode: 201, Headers: header("Content-Type", "application/json", "X-Request-ID", "a"), Body: `{"id":1,"served_at":"x"}`},
+			{RequestIndex: 1, StatusCode: 201, Headers: header("Content-Type", "application/json", "X-Request-ID", "b"), Body: `{"served_at":"y","id":1}`},
+			{RequestIndex: 3, Error: errors.New("connection reset")},
+		},
+	}
+
+	report, err := Diff(result)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	if report.Reference != 1 {
+		t.Errorf("Reference = %d, want 1", report.Reference)
+	}
+	if len(report.Identical) != 1 || report.Identical[0] != 2 {
+		t.Errorf("Identical = %v, want [2]", report.Identical)
+	}
+	if len(report.Deviations) != 2 {
+		t.Fatalf("Deviations = %+v, want 2", report.Deviations)
+	}
+	if d := report.Deviations[0]; d.Index != 3 || !strings.Contains(d.Diff, "-status: 201") || !strings.Contains(d.Diff, "+status: 409") {
+		t.Errorf("unexpected diff for [3]:\n%s", d.Diff)
+	}
+	if d := report.Deviations[1]; !strings.Contains(d.Diff, "+error: connection reset") {
+		t.Errorf("unexpected diff for [4]:\n%s", d.Diff)
+	}
+}
+
+func TestDiffSelectedHeaders(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Count = 2
+	cfg.DiffReference = 2
+	cfg.DiffHeaders = []string{"etag"}
+
+	result := &runner.Result{
+		Config: cfg,
+		Responses: []*client.Response{
+			{RequestIndex: 0, StatusCode: 200, Headers: http.Header{"Etag": {"v1"}, "Server": {"a"}}},
+			{RequestIndex: 1, StatusCode: 200, Headers: http.Header{"Etag": {"v2"}, "Server": {"b"}}},
+		},
+	}
+
+	report, err := Diff(result)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if report.Reference != 2 || len(report.Deviations) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	diff := report.Deviations[0].Diff
+	if !strings.Contains(diff, "-header Etag: v2") || strings.Contains(diff, "Server") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
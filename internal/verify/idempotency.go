@@ -0,0 +1,133 @@
+// Package verify compares responses of a run against each other.
+package verify
+
+import (
+	"sort"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/i18n"
+	"github.com/shiroemons/conreq/internal/jsonpath"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+// Verdicts of a verification.
+const (
+	VerdictPass = "PASS"
+	VerdictFail = "FAIL"
+)
+
+// Violation describes why a response broke the expected behaviour.
+// Index is the 1-based request index, or 0 for run-wide violations.
+type Violation struct {
+	Index  int
+	Reason string
+}
+
+// IdempotencyReport is the outcome of an idempotency-key verification.
+type IdempotencyReport struct {
+	Header     string
+	Key        string
+	Verdict    string
+	Reference  int   // 処理を行ったとみなすレスポンス（最初に完了した2xx）
+	Replays    []int // 参照レスポンスと同一だったレスポンス
+	Conflicts  []int // 競合ステータスを返したレスポンス
+	Violations []Violation
+}
+
+// Passed reports whether the verification passed.
+func (r *IdempotencyReport) Passed() bool {
+	return r.Verdict == VerdictPass
+}
+
+// CheckIdempotency verifies that exactly one request did the work: the first
+// 2xx response to complete is the reference, every other 2xx response must be
+// an identical replay (same status and body after removing the ignored JSON
+// paths), and every non-2xx response must use one of the conflict statuses.
+func CheckIdempotency(result *runner.Result) (*IdempotencyReport, error) {
+	cfg := result.Config
+	ignore, err := jsonpath.ParseAll(cfg.IgnoreJSONPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &IdempotencyReport{
+		Header:     cfg.IdempotencyHeader,
+		Replays:    make([]int, 0),
+		Conflicts:  make([]int, 0),
+		Violations: make([]Violation, 0),
+	}
+
+	conflict := make(map[int]bool, len(cfg.ConflictStatuses))
+	for _, status := range cfg.ConflictStatuses {
+		conflict[status] = true
+	}
+
+	// 完了順に並べ、最初に完了した2xxを参照レスポンスとする
+	responses := make([]*client.Response, len(result.Responses))
+	copy(responses, result.Responses)
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].Timestamp.Add(responses[i].Duration).Before(responses[j].Timestamp.Add(responses[j].Duration))
+	})
+
+	var reference *client.Response
+	var referenceBody string
+	for _, resp := range responses {
+		if report.Key == "" {
+			report.Key = idempotencyKey(resp, cfg.IdempotencyHeader, cfg.RequestIDHeader)
+		}
+
+		index := resp.RequestIndex + 1
+		switch {
+		case resp.Error != nil:
+			report.Violations = append(report.Violations, Violation{index, i18n.T("report.violation_request_failed", resp.Error)})
+		case isSuccess(resp.StatusCode) && reference == nil:
+			reference = resp
+			referenceBody, _ = jsonpath.NormalizeBody(resp.Body, ignore)
+			report.Reference = index
+		case isSuccess(resp.StatusCode):
+			body, _ := jsonpath.NormalizeBody(resp.Body, ignore)
+			switch {
+			case resp.StatusCode != reference.StatusCode:
+				report.Violations = append(report.Violations, Violation{index,
+					i18n.T("report.violation_status_differs", resp.StatusCode, report.Reference, reference.StatusCode)})
+			case body != referenceBody:
+				report.Violations = append(report.Violations, Violation{index,
+					i18n.T("report.violation_body_differs", report.Reference)})
+			default:
+				report.Replays = append(report.Replays, index)
+			}
+		case conflict[resp.StatusCode]:
+			report.Conflicts = append(report.Conflicts, index)
+		default:
+			report.Violations = append(report.Violations, Violation{index,
+				i18n.T("report.violation_unexpected_status", resp.StatusCode)})
+		}
+	}
+
+	if reference == nil {
+		report.Violations = append(report.Violations, Violation{0, i18n.T("report.violation_no_success")})
+	}
+
+	sort.Ints(report.Replays)
+	sort.Ints(report.Conflicts)
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		return report.Violations[i].Index < report.Violations[j].Index
+	})
+
+	report.Verdict = VerdictPass
+	if len(report.Violations) > 0 {
+		report.Verdict = VerdictFail
+	}
+	return report, nil
+}
+
+func idempotencyKey(resp *client.Response, header, requestIDHeader string) string {
+	if header == requestIDHeader {
+		return resp.RequestID
+	}
+	return resp.CorrelationIDs[header]
+}
+
+func isSuccess(status int) bool {
+	return status >= 200 && status < 300
+}
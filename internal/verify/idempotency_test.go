@@ -0,0 +1,137 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shiroemons/conreq/internal/client"
+	"github.com/shiroemons/conreq/internal/config"
+	"github.com/shiroemons/conreq/internal/runner"
+)
+
+func newResult(responses ...*client.Response) *runner.Result {
+	cfg := config.NewConfig()
+	cfg.Count = len(responses)
+	cfg.EnableIdempotency()
+	cfg.IgnoreJSONPaths = []string{"$.served_at"}
+
+	start := time.Now()
+	for i, resp := range responses {
+		resp.RequestIndex = i
+		resp.Timestamp = start
+		resp.Duration = time.Duration(i+1) * time.Millisecond
+		resp.CorrelationIDs = map[string]string{"Idempotency-Key": "key-1"}
+	}
+	return &runner.Result{Config: cfg, Responses: responses}
+}
+
+func TestCheckIdempotency(t *testing.T) {
+	tests := []struct {
+		name           string
+		responses      []*client.Response
+		wantVerdict    string
+		wantReplays    int
+		wantConflicts  int
+		wantViolations int
+	}{
+		{
+			name: "identical replays",
+			responses: []*client.Response{
+				{StatusCode: 201, Body: `{"id":1,"served_at":"a"}`},
+				{StatusCode: 201, Body: `{"served_at":"b","id":1}`},
+				{StatusCode: 201, Body: `{"id":1,"served_at":"c"}`},
+			},
+			wantVerdict: VerdictPass,
+			wantReplays: 2,
+		},
+		{
+			name: "replay and conflict",
+			responses: []*client.Response{
+				{StatusCode: 201, Body: `{"id":1}`},
+				{StatusCode: 409, Body: `{"error":"in progress"}`},
+			},
+			wantVerdict:   VerdictPass,
+			wantConflicts: 1,
+		},
+		{
+			name: "different body",
+			responses: []*client.Response{
+				{StatusCode: 201, Body: `{"id":1}`},
+				{StatusCode: 201, Body: `{"id":2}`},
+			},
+			wantVerdict:    VerdictFail,
+			wantViolations: 1,
+		},
+		{
+			name: "different success status",
+			responses: []*client.Response{
+				{StatusCode: 201, Body: `{"id":1}`},
+				{StatusCode: 200, Body: `{"id":1}`},
+			},
+			wantVerdict:    VerdictFail,
+			wantViolations: 1,
+		},
+		{
+			name: "unexpected status and network error",
+			responses: []*client.Response{
+				{StatusCode: 201, Body: `{"id":1}`},
+				{StatusCode: 500},
+				{Error: errors.New("connection refused")},
+			},
+			wantVerdict:    VerdictFail,
+			wantViolations: 2,
+		},
+		{
+			name: "no success",
+			responses: []*client.Response{
+				{StatusCode: 409},
+				{StatusCode: 409},
+			},
+			wantVerdict:    VerdictFail,
+			wantConflicts:  2,
+			wantViolations: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CheckIdempotency(newResult(tt.responses...))
+			if err != nil {
+				t.Fatalf("CheckIdempotency() error = %v", err)
+			}
+			if report.Verdict != tt.wantVerdict {
+				t.Errorf("Verdict = %s, want %s (violations: %v)", report.Verdict, tt.wantVerdict, report.Violations)
+			}
+			if len(report.Replays) != tt.wantReplays {
+				t.Errorf("Replays = %v, want %d", report.Replays, tt.wantReplays)
+			}
+			if len(report.Conflicts) != tt.wantConflicts {
+				t.Errorf("Conflicts = %v, want %d", report.Conflicts, tt.wantConflicts)
+			}
+			if len(report.Violations) != tt.wantViolations {
+				t.Errorf("Violations = %v, want %d", report.Violations, tt.wantViolations)
+			}
+			if report.Key != "key-1" {
+				t.Errorf("Key = %q, want key-1", report.Key)
+			}
+		})
+	}
+}
+
+func TestCheckIdempotencyReferenceIsFirstCompleted(t *testing.T) {
+	result := newResult(
+		&client.Response{StatusCode: 201, Body: `{"id":1}`},
+		&client.Response{StatusCode: 201, Body: `{"id":1}`},
+	)
+	// 2番目のリクエストが先に完了した場合はそれが参照レスポンスになる
+	result.Responses[0].Duration = 10 * time.Millisecond
+
+	report, err := CheckIdempotency(result)
+	if err != nil {
+		t.Fatalf("CheckIdempotency() error = %v", err)
+	}
+	if report.Reference != 2 {
+		t.Errorf("Reference = %d, want 2", report.Reference)
+	}
+}
@@ -0,0 +1,61 @@
+package requestid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors reported by Register and New. The returned errors are
+// *FormatError values that wrap one of these, so callers can match them
+// with errors.Is and render their own messages from the FormatError fields.
+var (
+	// ErrInvalidName means the format name is empty or contains a colon.
+	ErrInvalidName = errors.New("invalid request ID format name")
+	// ErrNilFactory means Register was called with a nil Factory.
+	ErrNilFactory = errors.New("nil request ID format factory")
+	// ErrDuplicate means the format name is already registered.
+	ErrDuplicate = errors.New("request ID format already registered")
+	// ErrUnsupported means no format is registered under the requested name.
+	ErrUnsupported = errors.New("unsupported request ID format")
+	// ErrUnexpectedArgument means an argument was given to a format that takes none.
+	ErrUnexpectedArgument = errors.New("request ID format takes no argument")
+	// ErrInvalidLength means the length argument of the nanoid format is out of range.
+	ErrInvalidLength = errors.New("invalid request ID length")
+)
+
+// FormatError describes why a request ID format could not be registered or
+// created. Its message is plain English and does not depend on any locale.
+type FormatError struct {
+	// Err is one of the sentinel errors above.
+	Err error
+	// Name is the format name.
+	Name string
+	// Arg is the format argument, the part after the colon.
+	Arg string
+	// Available lists the registered format names for ErrUnsupported.
+	Available []string
+}
+
+func (e *FormatError) Error() string {
+	switch e.Err {
+	case ErrInvalidName:
+		return fmt.Sprintf("invalid request ID format name: %q", e.Name)
+	case ErrNilFactory:
+		return fmt.Sprintf("the Factory of request ID format %q is nil", e.Name)
+	case ErrDuplicate:
+		return fmt.Sprintf("request ID format %q is already registered", e.Name)
+	case ErrUnsupported:
+		return fmt.Sprintf("unsupported request ID format: %q (available: %s)", e.Name, strings.Join(e.Available, ", "))
+	case ErrUnexpectedArgument:
+		return fmt.Sprintf("request ID format %q takes no argument: %q", e.Name, e.Arg)
+	case ErrInvalidLength:
+		return fmt.Sprintf("the %s length must be between 1 and 255: %q", e.Name, e.Arg)
+	default:
+		return fmt.Sprintf("request ID format %q: %v", e.Name, e.Err)
+	}
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,205 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func init() {
+	MustRegister("uuid", noArg("uuid", uuidV4{}))
+	MustRegister("uuidv7", noArg("uuidv7", uuidV7{}))
+	MustRegister("ulid", noArg("ulid", ulid{}))
+	MustRegister("ksuid", noArg("ksuid", ksuid{}))
+	MustRegister("nanoid", newNanoID)
+	MustRegister("sequential", newSequential)
+}
+
+func noArg(name string, g Generator) Factory {
+	return func(arg string) (Generator, error) {
+		if arg != "" {
+			return nil, &FormatError{Err: ErrUnexpectedArgument, Name: name, Arg: arg}
+		}
+		return g, nil
+	}
+}
+
+// uuidV4 generates random UUIDs (version 4).
+type uuidV4 struct{}
+
+func (uuidV4) Generate() string { return Generate() }
+
+func (uuidV4) Validate(id string) bool { return IsValid(id) }
+
+// uuidV7 generates time-ordered UUIDs (version 7).
+type uuidV7 struct{}
+
+func (uuidV7) Generate() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+func (uuidV7) Validate(id string) bool {
+	u, err := uuid.Parse(id)
+	return err == nil && u.Version() == 7
+}
+
+// crockford is the Crockford base32 alphabet used by ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var ulidPattern = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+
+// ulid generates ULIDs: a 48-bit millisecond timestamp and 80 random bits,
+// encoded as 26 Crockford base32 characters.
+type ulid struct{}
+
+func (ulid) Generate() string {
+	var data [16]byte
+	ms := uint64(time.Now().UnixMilli()) //nolint:gosec // timestamps are positive
+	data[0] = byte(ms >> 40)
+	data[1] = byte(ms >> 32)
+	data[2] = byte(ms >> 24)
+	data[3] = byte(ms >> 16)
+	data[4] = byte(ms >> 8)
+	data[5] = byte(ms)
+	_, _ = rand.Read(data[6:])
+
+	// 128ビットを先頭2ビットのパディング付きで5ビットずつエンコード
+	hi := binary.BigEndian.Uint64(data[:8])
+	lo := binary.BigEndian.Uint64(data[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+func (ulid) Validate(id string) bool {
+	return ulidPattern.MatchString(strings.ToUpper(id))
+}
+
+// ksuidEpoch is the KSUID epoch (2014-05-13T16:53:20Z).
+const ksuidEpoch = 1400000000
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	ksuidPattern = regexp.MustCompile(`^[0-9A-Za-z]{27}$`)
+	// ksuidMax is the largest 20-byte KSUID payload, "aWgEPTl1tmebfsQzFP4bxwgy80V" in base62.
+	ksuidMax = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+)
+
+// ksuid generates KSUIDs: a 32-bit second timestamp and 128 random bits,
+// encoded as 27 base62 characters.
+type ksuid struct{}
+
+func (ksuid) Generate() string {
+	var data [20]byte
+	binary.BigEndian.PutUint32(data[:4], uint32(time.Now().Unix()-ksuidEpoch)) //nolint:gosec // fits until 2150
+	_, _ = rand.Read(data[4:])
+
+	n := new(big.Int).SetBytes(data[:])
+	out := make([]byte, 27)
+	for i := range out {
+		out[i] = '0'
+	}
+	base := big.NewInt(62)
+	mod := new(big.Int)
+	for i := 26; i >= 0 && n.Sign() > 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = base62[mod.Int64()]
+	}
+	return string(out)
+}
+
+func (ksuid) Validate(id string) bool {
+	if !ksuidPattern.MatchString(id) {
+		return false
+	}
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for _, c := range []byte(id) {
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(strings.IndexByte(base62, c))))
+	}
+	return n.Cmp(ksuidMax) <= 0
+}
+
+const nanoIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+
+const defaultNanoIDSize = 21
+
+// nanoID generates URL-safe random IDs of a fixed length.
+type nanoID struct {
+	size    int
+	pattern *regexp.Regexp
+}
+
+// newNanoID accepts an optional length, e.g. "nanoid:12".
+func newNanoID(arg string) (Generator, error) {
+	size := defaultNanoIDSize
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > 255 {
+			return nil, &FormatError{Err: ErrInvalidLength, Name: "nanoid", Arg: arg}
+		}
+		size = n
+	}
+	return &nanoID{
+		size:    size,
+		pattern: regexp.MustCompile(fmt.Sprintf(`^[A-Za-z0-9_-]{%d}$`, size)),
+	}, nil
+}
+
+func (g *nanoID) Generate() string {
+	buf := make([]byte, g.size)
+	_, _ = rand.Read(buf)
+	for i, b := range buf {
+		// 64文字のアルファベットなので下位6ビットで偏りなく選択できる
+		buf[i] = nanoIDAlphabet[b&63]
+	}
+	return string(buf)
+}
+
+func (g *nanoID) Validate(id string) bool {
+	return g.pattern.MatchString(id)
+}
+
+const defaultSequentialPrefix = "req"
+
+// sequential generates "prefix-000001", "prefix-000002", ... starting at 1.
+// The counter belongs to the generator, so each run starts from 1.
+type sequential struct {
+	prefix  string
+	counter atomic.Int64
+	pattern *regexp.Regexp
+}
+
+// newSequential accepts an optional prefix, e.g. "sequential:order".
+func newSequential(arg string) (Generator, error) {
+	prefix := arg
+	if prefix == "" {
+		prefix = defaultSequentialPrefix
+	}
+	return &sequential{
+		prefix:  prefix,
+		pattern: regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `-\d{6,}$`),
+	}, nil
+}
+
+func (g *sequential) Generate() string {
+	return fmt.Sprintf("%s-%06d", g.prefix, g.counter.Add(1))
+}
+
+func (g *sequential) Validate(id string) bool {
+	return g.pattern.MatchString(id)
+}
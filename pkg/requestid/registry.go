@@ -0,0 +1,87 @@
+package requestid
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultFormat is the format used when none is specified.
+const DefaultFormat = "uuid"
+
+// Generator produces request IDs of a single format.
+// Implementations must be safe for concurrent use.
+type Generator interface {
+	// Generate returns a new request ID.
+	Generate() string
+	// Validate reports whether id is a well-formed ID of this format.
+	Validate(id string) bool
+}
+
+// Factory creates a Generator. arg is the part after the colon in a format
+// specification such as "sequential:order", and is empty when omitted.
+type Factory func(arg string) (Generator, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a generator format available under name.
+// Embedding programs can use it to add custom formats; registering a name
+// twice returns an error.
+func Register(name string, factory Factory) error {
+	if name == "" || strings.Contains(name, ":") {
+		return &FormatError{Err: ErrInvalidName, Name: name}
+	}
+	if factory == nil {
+		return &FormatError{Err: ErrNilFactory, Name: name}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[name]; exists {
+		return &FormatError{Err: ErrDuplicate, Name: name}
+	}
+	registry[name] = factory
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(name string, factory Factory) {
+	if err := Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// New returns a Generator for the format specification "name" or "name:arg".
+// An empty specification selects DefaultFormat.
+func New(spec string) (Generator, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+	if name == "" {
+		name = DefaultFormat
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, &FormatError{Err: ErrUnsupported, Name: name, Arg: arg, Available: Formats()}
+	}
+	return factory(arg)
+}
+
+// Formats returns the registered format names in sorted order.
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
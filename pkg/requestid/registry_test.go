@@ -0,0 +1,179 @@
+package requestid
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuiltinFormats(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantLen   int
+		wantValid []string
+		wantBad   []string
+	}{
+		{"", 36, []string{"550e8400-e29b-41d4-a716-446655440000"}, []string{"not-a-uuid"}},
+		{"uuid", 36, nil, []string{""}},
+		{"uuidv7", 36, []string{"01890a5d-ac96-774b-bcce-b302099a8057"}, []string{"550e8400-e29b-41d4-a716-446655440000"}},
+		{"ulid", 26, []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV"}, []string{"01ARZ3NDEKTSV4RRFFQ69G5FAU!", "81ARZ3NDEKTSV4RRFFQ69G5FAV"}},
+		{"ksuid", 27, []string{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", "aWgEPTl1tmebfsQzFP4bxwgy80V"}, []string{"aWgEPTl1tmebfsQzFP4bxwgy80W", "short"}},
+		{"nanoid", 21, []string{"V1StGXR8_Z5jdHi6B-myT"}, []string{"V1StGXR8_Z5jdHi6B-my", "V1StGXR8_Z5jdHi6B-my!"}},
+		{"nanoid:10", 10, []string{"IRFa-VaY2b"}, []string{"V1StGXR8_Z5jdHi6B-myT"}},
+		{"sequential", 10, []string{"req-000123", "req-1234567"}, []string{"req-12", "order-000001"}},
+		{"sequential:order", 12, []string{"order-000001"}, []string{"req-000001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			gen, err := New(tt.spec)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tt.spec, err)
+			}
+
+			id1 := gen.Generate()
+			id2 := gen.Generate()
+			if len(id1) != tt.wantLen {
+				t.Errorf("Generate() = %q, want length %d", id1, tt.wantLen)
+			}
+			if id1 == id2 {
+				t.Errorf("Generate() returned the same ID twice: %q", id1)
+			}
+			for _, id := range []string{id1, id2} {
+				if !gen.Validate(id) {
+					t.Errorf("Validate(%q) = false for a generated ID", id)
+				}
+			}
+			for _, id := range tt.wantValid {
+				if !gen.Validate(id) {
+					t.Errorf("Validate(%q) = false, want true", id)
+				}
+			}
+			for _, id := range tt.wantBad {
+				if gen.Validate(id) {
+					t.Errorf("Validate(%q) = true, want false", id)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeOrderedFormats(t *testing.T) {
+	for _, spec := range []string{"uuidv7", "ulid"} {
+		t.Run(spec, func(t *testing.T) {
+			gen, err := New(spec)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", spec, err)
+			}
+			earlier := gen.Generate()
+			time.Sleep(2 * time.Millisecond)
+			later := gen.Generate()
+			if earlier >= later {
+				t.Errorf("IDs are not time-ordered: %q >= %q", earlier, later)
+			}
+		})
+	}
+}
+
+func TestSequentialCounter(t *testing.T) {
+	gen, err := New("sequential:job")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	seen := sync.Map{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := gen.Generate()
+			if _, loaded := seen.LoadOrStore(id, true); loaded {
+				t.Errorf("duplicate sequential ID %q", id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := gen.Generate(); got != "job-000051" {
+		t.Errorf("Generate() = %q, want job-000051", got)
+	}
+
+	other, _ := New("sequential:job")
+	if got := other.Generate(); got != "job-000001" {
+		t.Errorf("new generator should restart at 1, got %q", got)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    error
+		wantMsg string
+	}{
+		{"unknown", ErrUnsupported, `unsupported request ID format: "unknown" (available: `},
+		{"uuid:arg", ErrUnexpectedArgument, `request ID format "uuid" takes no argument: "arg"`},
+		{"nanoid:0", ErrInvalidLength, `the nanoid length must be between 1 and 255: "0"`},
+		{"nanoid:abc", ErrInvalidLength, `the nanoid length must be between 1 and 255: "abc"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := New(tt.spec)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("New(%q) error = %v, want %v", tt.spec, err, tt.want)
+			}
+			var formatErr *FormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("New(%q) error = %T, want *FormatError", tt.spec, err)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantMsg) {
+				t.Errorf("New(%q) error = %q, want prefix %q", tt.spec, err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+type fixedGenerator struct{}
+
+func (fixedGenerator) Generate() string        { return "custom-id" }
+func (fixedGenerator) Validate(id string) bool { return strings.HasPrefix(id, "custom-") }
+
+func TestRegister(t *testing.T) {
+	factory := func(string) (Generator, error) { return fixedGenerator{}, nil }
+
+	if err := Register("test-custom", factory); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := Register("test-custom", factory); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("Register() of a duplicate name error = %v, want %v", err, ErrDuplicate)
+	}
+	for _, name := range []string{"", "bad:name"} {
+		if err := Register(name, factory); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Register(%q) error = %v, want %v", name, err, ErrInvalidName)
+		}
+	}
+	if err := Register("test-nil", nil); !errors.Is(err, ErrNilFactory) {
+		t.Errorf("Register() with nil factory error = %v, want %v", err, ErrNilFactory)
+	}
+
+	gen, err := New("test-custom")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := gen.Generate(); got != "custom-id" {
+		t.Errorf("Generate() = %q, want custom-id", got)
+	}
+
+	found := false
+	for _, name := range Formats() {
+		if name == "test-custom" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Formats() = %v, missing test-custom", Formats())
+	}
+}